@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"math"
 	"math/big"
-	"strconv"
 	"sync"
 	"time"
 
@@ -23,6 +22,7 @@ type Player struct {
 	position     int
 	tempo        int
 	notes        []PlayableNote
+	columns      []int // Tab column of each playback step
 	highlighted  []models.Position
 	stopChan     chan bool
 	currentTab   *models.Tab
@@ -38,7 +38,8 @@ type PlayableNote struct {
 	Duration  time.Duration
 	Volume    float64
 	String    int
-	Position  int
+	Position  int // Tab column, used for highlighting
+	Step      int // Column of the unrolled playback timeline
 }
 
 func NewPlayer() *Player {
@@ -77,6 +78,7 @@ func (p *Player) PlayTab(tab *models.Tab) error {
 
 	p.currentTab = tab
 	p.notes = p.convertTabToNotes(tab)
+	p.columns = tab.PlaybackColumns()
 	fmt.Printf("Converted tab to %d notes\n", len(p.notes))
 
 	if len(p.notes) == 0 {
@@ -140,6 +142,20 @@ func (p *Player) GetHighlighted() []models.Position {
 	return result
 }
 
+// PlayingColumn returns the tab column of the step being played, whether
+// it holds notes or not. ok is false when nothing is playing.
+func (p *Player) PlayingColumn() (column int, ok bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// The position has already moved on to the next step
+	step := p.position - 1
+	if !p.isPlaying || step < 0 || step >= len(p.columns) {
+		return 0, false
+	}
+	return p.columns[step], true
+}
+
 func (p *Player) GetPosition() int {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -149,11 +165,12 @@ func (p *Player) GetPosition() int {
 func (p *Player) convertTabToNotes(tab *models.Tab) []PlayableNote {
 	var notes []PlayableNote
 
-	// Standard guitar tuning frequencies (in Hz)
-	// E(6th) A(5th) D(4th) G(3rd) B(2nd) e(1st) - but our array is reversed
-	stringFrequencies := [6]float64{329.63, 246.94, 196.00, 146.83, 110.00, 82.41} // e B G D A E (high to low as displayed)
-
-	maxLength := tab.GetTotalLength()
+	// Repeats and jumps are unrolled so the same column can sound several times
+	columns := tab.PlaybackColumns()
+	notesByColumn := make(map[int][]models.Note)
+	for _, note := range tab.Notes() {
+		notesByColumn[note.Position] = append(notesByColumn[note.Position], note)
+	}
 
 	// Use the tab's tempo if available, otherwise default
 	tempo := tab.Tempo
@@ -164,25 +181,21 @@ func (p *Player) convertTabToNotes(tab *models.Tab) []PlayableNote {
 	// Calculate note duration based on tempo (assume 16th notes)
 	beatDuration := time.Minute / time.Duration(tempo*4)
 
-	for pos := 0; pos < maxLength; pos++ {
-		for stringIdx, line := range tab.Content {
-			if pos < len(line) && line[pos] != '-' && line[pos] != '|' && line[pos] != ' ' {
-				if fret, err := strconv.Atoi(string(line[pos])); err == nil && fret >= 0 && fret <= 24 {
-					// Calculate frequency based on fret position
-					// Each fret increases frequency by a factor of 2^(1/12)
-					frequency := stringFrequencies[stringIdx] * math.Pow(2, float64(fret)/12.0)
-
-					note := PlayableNote{
-						Frequency: frequency,
-						Start:     time.Duration(pos) * beatDuration,
-						Duration:  beatDuration * 3 / 4, // Note length (slightly shorter than beat)
-						Volume:    0.3,                  // Increased volume for guitar synthesis
-						String:    stringIdx,
-						Position:  pos,
-					}
-					notes = append(notes, note)
-				}
+	for step, pos := range columns {
+		for _, tabNote := range notesByColumn[pos] {
+			// Pitch follows the tab's tuning and capo
+			midiNote := tab.MIDINote(tabNote.String, tabNote.Fret)
+
+			note := PlayableNote{
+				Frequency: midiFrequency(midiNote),
+				Start:     time.Duration(step) * beatDuration,
+				Duration:  beatDuration * 3 / 4, // Note length (slightly shorter than beat)
+				Volume:    0.3,                  // Increased volume for guitar synthesis
+				String:    tabNote.String,
+				Position:  pos,
+				Step:      step,
 			}
+			notes = append(notes, note)
 		}
 	}
 
@@ -224,14 +237,14 @@ func (p *Player) playbackLoop() {
 
 	maxPos := 0
 	for _, note := range p.notes {
-		if note.Position > maxPos {
-			maxPos = note.Position
+		if note.Step > maxPos {
+			maxPos = note.Step
 		}
 	}
 
-	// If no notes, determine max position from tab content
+	// If no notes, determine max position from the playback timeline
 	if maxPos == 0 && p.currentTab != nil {
-		maxPos = p.currentTab.GetTotalLength()
+		maxPos = len(p.currentTab.PlaybackColumns())
 	}
 
 	fmt.Printf("Playback range: 0 to %d positions\n", maxPos)
@@ -253,7 +266,7 @@ func (p *Player) playbackLoop() {
 			p.highlighted = nil
 			notesAtPosition := 0
 			for _, note := range p.notes {
-				if note.Position == p.position {
+				if note.Step == p.position {
 					p.highlighted = append(p.highlighted, models.Position{
 						String:   note.String,
 						Position: note.Position,
@@ -285,6 +298,11 @@ func (p *Player) playbackLoop() {
 }
 
 func (p *Player) playNote(note PlayableNote) {
+	p.playNoteAfter(note, 0)
+}
+
+// playNoteAfter adds a note to the mixer that starts after the given delay
+func (p *Player) playNoteAfter(note PlayableNote, delay time.Duration) {
 	// Create a Karplus-Strong synthesized guitar note
 	generator := NewKarplusStrong(note.Frequency, p.sampleRate, note.Duration)
 
@@ -299,20 +317,59 @@ func (p *Player) playNote(note PlayableNote) {
 	// Create a limited duration streamer
 	duration := p.sampleRate.N(note.Duration)
 	limited := beep.Take(duration, volume)
+	streamer := beep.Streamer(limited)
+	if delay > 0 {
+		streamer = beep.Seq(beep.Silence(p.sampleRate.N(delay)), limited)
+	}
 
 	// Add to mixer
 	speaker.Lock()
-	p.mixer.Add(limited)
+	p.mixer.Add(streamer)
 	speaker.Unlock()
 }
 
+// strumDelay is the gap between strings when a chord is previewed
+const strumDelay = 25 * time.Millisecond
+
+// PlayChord strums the given MIDI notes from the last to the first, so notes
+// in tab order (highest string first) are strummed downwards. It is used to
+// preview chord shapes and does nothing while the tab is playing.
+func (p *Player) PlayChord(midiNotes []int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.isPlaying {
+		return
+	}
+
+	speaker.Lock()
+	p.mixer.Clear()
+	p.ctrl.Paused = false
+	speaker.Unlock()
+
+	for i := len(midiNotes) - 1; i >= 0; i-- {
+		offset := time.Duration(len(midiNotes)-1-i) * strumDelay
+		p.playNoteAfter(PlayableNote{
+			Frequency: midiFrequency(midiNotes[i]),
+			Duration:  1500 * time.Millisecond,
+			Volume:    0.3,
+		}, offset)
+	}
+}
+
+// midiFrequency converts a MIDI note number to Hz; each semitone raises
+// the frequency by a factor of 2^(1/12) from A4 = 440 Hz
+func midiFrequency(midiNote int) float64 {
+	return 440 * math.Pow(2, float64(midiNote-69)/12.0)
+}
+
 func (p *Player) GetPlaybackInfo() (position int, totalLength int, isPlaying bool) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
 	maxPos := 0
 	if p.currentTab != nil {
-		maxPos = p.currentTab.GetTotalLength()
+		maxPos = len(p.currentTab.PlaybackColumns())
 	}
 
 	return p.position, maxPos, p.isPlaying
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/gopxl/beep"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
 )
 
 func TestKarplusStrong(t *testing.T) {
@@ -90,3 +92,37 @@ func TestKarplusStrongDuration(t *testing.T) {
 		t.Errorf("Expected approximately %d samples, got %d", expectedSamples, totalSamples)
 	}
 }
+
+func TestPlayingColumn(t *testing.T) {
+	// Measure 1 is repeated, so the steps after it go back to column 0
+	tab := models.NewEmptyTab("Follow")
+	tab.SetFret(0, 0, 3)
+	tab.SetForm(models.MeasureForm{Measure: 0, RepeatEnd: 2})
+
+	p := &Player{isPlaying: true, columns: tab.PlaybackColumns()}
+	steps := []struct {
+		position int
+		column   int
+		ok       bool
+	}{
+		{0, 0, false}, // Nothing played yet
+		{1, 0, true},
+		{6, 5, true}, // A rest is followed all the same
+		{17, 0, true},
+		{33, 16, true},
+		{len(p.columns) + 1, 0, false}, // Past the end
+	}
+	for _, step := range steps {
+		p.position = step.position
+		column, ok := p.PlayingColumn()
+		if ok != step.ok || column != step.column {
+			t.Errorf("Position %d: expected column %d %v, got %d %v", step.position, step.column, step.ok, column, ok)
+		}
+	}
+
+	p.isPlaying = false
+	p.position = 1
+	if _, ok := p.PlayingColumn(); ok {
+		t.Error("Expected no column when not playing")
+	}
+}
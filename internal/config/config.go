@@ -0,0 +1,125 @@
+// internal/config/config.go
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// Config holds the preferences read from config.toml. Settings left out
+// of the file keep their defaults.
+type Config struct {
+	Database   string `toml:"database"`        // SQLite file the tabs are kept in
+	MaxStretch int    `toml:"max_stretch"`     // Widest chord span not reported as a playability warning
+	ShiftScope string `toml:"shift_scope"`     // "measure" or "tab", see models.ShiftScope
+	Theme      string `toml:"theme"`           // Built-in theme: dark, light, high-contrast or mono
+	Follow     bool   `toml:"follow_playback"` // Scroll the editor along with playback
+
+	// Colors changes the colors of the theme by role, such as
+	// selected = { fg = "15", bg = "#005f87" }
+	Colors map[string]Colors `toml:"colors"`
+
+	// Keys rebinds actions by name, such as save = "ctrl+w" or
+	// left = ["h", "left"]. The keys replace the default ones.
+	Keys map[string]Keys `toml:"keys"`
+}
+
+// Colors are the foreground and background of a role, as ANSI numbers or
+// hex values; an empty one keeps the color of the theme
+type Colors struct {
+	Fg string `toml:"fg"`
+	Bg string `toml:"bg"`
+}
+
+// Keys is the key or list of keys bound to an action
+type Keys []string
+
+// UnmarshalTOML takes a single key as well as a list
+func (k *Keys) UnmarshalTOML(value any) error {
+	switch value := value.(type) {
+	case string:
+		*k = Keys{value}
+	case []any:
+		keys := make(Keys, len(value))
+		for i, v := range value {
+			s, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("key %v is not a string", v)
+			}
+			keys[i] = s
+		}
+		*k = keys
+	default:
+		return fmt.Errorf("keys must be a string or a list of strings, got %v", value)
+	}
+	return nil
+}
+
+// Default returns the configuration used without a config file
+func Default() Config {
+	return Config{
+		Database:   "tabs.db",
+		MaxStretch: models.DefaultMaxStretch,
+		ShiftScope: "measure",
+		Theme:      "dark",
+		Follow:     true,
+	}
+}
+
+// Path returns where the config file is looked for: tuitar/config.toml
+// in the user's config directory ($XDG_CONFIG_HOME or ~/.config on Linux)
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tuitar", "config.toml"), nil
+}
+
+// Load reads the config file at path over the defaults. A missing file
+// is not an error.
+func Load(path string) (Config, error) {
+	cfg := Default()
+	meta, err := toml.DecodeFile(path, &cfg)
+	if errors.Is(err, fs.ErrNotExist) {
+		cfg, err = Default(), nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	// NO_COLOR (https://no-color.org) asks for no color, unless the config
+	// file names a theme
+	if os.Getenv("NO_COLOR") != "" && !meta.IsDefined("theme") {
+		cfg.Theme = "mono"
+	}
+	return cfg, cfg.validate()
+}
+
+func (c Config) validate() error {
+	if c.MaxStretch < 1 || c.MaxStretch > models.MaxFret {
+		return fmt.Errorf("max_stretch must be from 1 to %d frets", models.MaxFret)
+	}
+	if _, ok := c.Scope(); !ok {
+		return fmt.Errorf("shift_scope must be \"measure\" or \"tab\", got %q", c.ShiftScope)
+	}
+	return nil
+}
+
+// Scope returns the shift scope named by ShiftScope
+func (c Config) Scope() (models.ShiftScope, bool) {
+	switch c.ShiftScope {
+	case "measure":
+		return models.ShiftMeasure, true
+	case "tab":
+		return models.ShiftTab, true
+	}
+	return models.ShiftMeasure, false
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	// Without a file the defaults apply
+	cfg, err := Load(filepath.Join(dir, "missing.toml"))
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, got %v", err)
+	}
+	if cfg.Database != "tabs.db" || cfg.MaxStretch != models.DefaultMaxStretch {
+		t.Errorf("Expected the defaults, got %+v", cfg)
+	}
+	if !cfg.Follow {
+		t.Error("Expected playback followed by default")
+	}
+
+	path := filepath.Join(dir, "config.toml")
+	data := `max_stretch = 6
+shift_scope = "tab"
+follow_playback = false
+
+[keys]
+save = "ctrl+w"
+left = ["h", "a"]
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Database != "tabs.db" || cfg.MaxStretch != 6 {
+		t.Errorf("Expected database kept and max stretch 6, got %+v", cfg)
+	}
+	if cfg.Follow {
+		t.Error("Expected follow_playback turned off")
+	}
+	if scope, _ := cfg.Scope(); scope != models.ShiftTab {
+		t.Errorf("Expected shift scope tab, got %v", scope)
+	}
+	if len(cfg.Keys["save"]) != 1 || cfg.Keys["save"][0] != "ctrl+w" {
+		t.Errorf("Expected save on ctrl+w, got %v", cfg.Keys["save"])
+	}
+	if len(cfg.Keys["left"]) != 2 || cfg.Keys["left"][1] != "a" {
+		t.Errorf("Expected left on h and a, got %v", cfg.Keys["left"])
+	}
+
+	if err := os.WriteFile(path, []byte(`shift_scope = "song"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error for an unknown shift scope")
+	}
+}
+
+func TestNoColor(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("NO_COLOR", "1")
+
+	cfg, err := Load(filepath.Join(dir, "missing.toml"))
+	if err != nil || cfg.Theme != "mono" {
+		t.Errorf("Expected the mono theme with NO_COLOR, got %q (%v)", cfg.Theme, err)
+	}
+
+	// A theme named in the file wins
+	path := filepath.Join(dir, "config.toml")
+	data := `theme = "light"
+
+[colors]
+selected = { fg = "15", bg = "#005f87" }
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Theme != "light" {
+		t.Errorf("Expected the light theme, got %q", cfg.Theme)
+	}
+	if colors := cfg.Colors["selected"]; colors.Fg != "15" || colors.Bg != "#005f87" {
+		t.Errorf("Expected the selected colors, got %+v", colors)
+	}
+}
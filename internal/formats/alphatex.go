@@ -0,0 +1,144 @@
+// internal/formats/alphatex.go
+package formats
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// alphaTexDurations maps a length in columns to an alphaTex duration and dot
+var alphaTexDurations = map[int]string{
+	16: "1",
+	12: "2{d}",
+	8:  "2",
+	6:  "4{d}",
+	4:  "4",
+	3:  "8{d}",
+	2:  "8",
+	1:  "16",
+}
+
+// alphaTexPitch spells a MIDI note number for an alphaTex tuning, e.g. "e4"
+func alphaTexPitch(midi int) string {
+	return fmt.Sprintf("%s%d", strings.ToLower(models.NoteNames[midi%12]), midi/12-1)
+}
+
+// alphaTexString quotes a string for use in alphaTex metadata
+func alphaTexString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// alphaTexJumps maps jump instructions to alphaTex \jump names
+var alphaTexJumps = map[models.Jump]string{
+	models.JumpDC:       "DaCapo",
+	models.JumpDCalFine: "DaCapoAlFine",
+	models.JumpDCalCoda: "DaCapoAlCoda",
+	models.JumpDS:       "DalSegno",
+	models.JumpDSalFine: "DalSegnoAlFine",
+	models.JumpDSalCoda: "DalSegnoAlCoda",
+}
+
+// alphaTexForm returns the bar metadata for the repeats, endings and
+// navigation markings of a measure
+func alphaTexForm(tab *models.Tab, measure int) string {
+	form := tab.FormAt(measure)
+	var meta []string
+	if form.RepeatStart {
+		meta = append(meta, "\\ro")
+	}
+	if form.RepeatEnd > 0 {
+		meta = append(meta, fmt.Sprintf("\\rc %d", form.RepeatEnd))
+	}
+	if form.Ending > 0 {
+		meta = append(meta, fmt.Sprintf("\\ae %d", form.Ending))
+	}
+	if form.Segno {
+		meta = append(meta, "\\jump Segno")
+	}
+	if form.Coda {
+		meta = append(meta, "\\jump Coda")
+	}
+	if form.ToCoda {
+		meta = append(meta, "\\jump DaCoda")
+	}
+	if form.Fine {
+		meta = append(meta, "\\jump Fine")
+	}
+	if name, ok := alphaTexJumps[form.Jump]; ok {
+		meta = append(meta, "\\jump "+name)
+	}
+	return strings.Join(meta, " ")
+}
+
+// WriteAlphaTex writes the tab in alphaTex, the text format understood by
+// alphaTab for rendering and printing
+func WriteAlphaTex(w io.Writer, tab *models.Tab) error {
+	beats, beatType, fits := measureMeter(tab)
+	openStrings := tab.OpenStringMIDI()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\\title %s\n", alphaTexString(tab.Name))
+	if tab.Artist != "" {
+		fmt.Fprintf(&b, "\\artist %s\n", alphaTexString(tab.Artist))
+	}
+	fmt.Fprintf(&b, "\\tempo %d\n", tempoOrDefault(tab))
+	b.WriteString(".\n")
+
+	var tuning []string
+	for _, midi := range openStrings {
+		tuning = append(tuning, alphaTexPitch(midi))
+	}
+	fmt.Fprintf(&b, "\\tuning %s\n", strings.Join(tuning, " "))
+	if tab.Capo > 0 {
+		fmt.Fprintf(&b, "\\capo %d\n", tab.Capo)
+	}
+	if !fits {
+		// alphaTab fills bars to the time signature, and every measure
+		// holds a whole note
+		fmt.Fprintf(&b, "// %s written as %d/%d, the length of a measure\n", tab.TimeSignature, beats, beatType)
+	}
+	fmt.Fprintf(&b, "\\ts %d %d\n", beats, beatType)
+
+	for measure := 0; measure < tab.GetMeasureCount(); measure++ {
+		var tokens []string
+		for _, event := range measureEvents(tab, measure) {
+			parts := splitDuration(event.Duration)
+			if event.Rest {
+				for _, d := range parts {
+					tokens = append(tokens, "r."+alphaTexDurations[d])
+				}
+				continue
+			}
+
+			var frets []string
+			for _, note := range event.Notes {
+				frets = append(frets, fmt.Sprintf("%d.%d", note.Fret, note.String+1))
+			}
+			beat := frets[0]
+			if len(frets) > 1 {
+				beat = "(" + strings.Join(frets, " ") + ")"
+			}
+			tokens = append(tokens, beat+"."+alphaTexDurations[parts[0]])
+			for _, d := range parts[1:] {
+				tokens = append(tokens, "r."+alphaTexDurations[d])
+			}
+		}
+
+		if meta := alphaTexForm(tab, measure); meta != "" {
+			tokens = append([]string{meta}, tokens...)
+		}
+
+		separator := " |"
+		if measure == tab.GetMeasureCount()-1 {
+			separator = ""
+		}
+		fmt.Fprintf(&b, "%s%s\n", strings.Join(tokens, " "), separator)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,122 @@
+// internal/formats/ascii.go
+package formats
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+	"github.com/Cod-e-Codes/tuitar/internal/theory"
+)
+
+// asciiMeasuresPerLine is how many measures make up one system of the
+// plain text tab
+const asciiMeasuresPerLine = 4
+
+// asciiLane lays out annotations over a system starting at column start,
+// pushing texts right when they would overlap. Every column is indented
+// by indent characters, and each measure after the first by its bar line.
+func asciiLane(lane []models.Annotation, start, measures, indent int) string {
+	end := start + measures*models.MeasureLength
+	var b strings.Builder
+	for _, a := range lane {
+		if a.Position < start || a.Position >= end {
+			continue
+		}
+		rel := a.Position - start
+		offset := indent + rel + rel/models.MeasureLength
+		if pad := offset - b.Len(); pad > 0 {
+			b.WriteString(strings.Repeat(" ", pad))
+		} else if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(a.Text)
+	}
+	return b.String()
+}
+
+// asciiSections labels the measures of a system where sections start
+func asciiSections(tab *models.Tab, first, measures, indent int) string {
+	var lane []models.Annotation
+	for i, section := range tab.Sections {
+		if section.Start >= first && section.Start < first+measures {
+			lane = append(lane, models.Annotation{
+				Position: section.Start * models.MeasureLength,
+				Text:     fmt.Sprintf("[%s] %s", models.RehearsalLetter(i), section.Name),
+			})
+		}
+	}
+	return asciiLane(lane, first*models.MeasureLength, measures, indent)
+}
+
+// WriteASCII writes the tab as plain text tablature, with the chord lane
+// and the recognized chord of each column above the staff and the lyrics
+// below it
+func WriteASCII(w io.Writer, tab *models.Tab) error {
+	var b strings.Builder
+	b.WriteString(tab.Name + "\n")
+	if tab.Artist != "" {
+		b.WriteString("by " + tab.Artist + "\n")
+	}
+
+	info := []string{"Tuning: " + strings.Join(reversed(tab.Tuning), " ")}
+	if tab.Capo > 0 {
+		info = append(info, fmt.Sprintf("Capo %d", tab.Capo))
+	}
+	info = append(info, fmt.Sprintf("Tempo %d", tempoOrDefault(tab)))
+	if tab.TimeSignature != "" {
+		info = append(info, tab.TimeSignature)
+	}
+	b.WriteString(strings.Join(info, " | ") + "\n")
+
+	// String names are padded so every staff line starts at the same column
+	labels := tab.StringLabels()
+	indent := len(labels[0]) + 1
+
+	recognized := theory.ColumnChords(tab)
+	for first := 0; first < tab.GetMeasureCount(); first += asciiMeasuresPerLine {
+		measures := asciiMeasuresPerLine
+		if first+measures > tab.GetMeasureCount() {
+			measures = tab.GetMeasureCount() - first
+		}
+		start := first * models.MeasureLength
+
+		b.WriteString("\n")
+		for _, lane := range []string{
+			asciiSections(tab, first, measures, indent),
+			asciiLane(tab.Chords, start, measures, indent),
+			asciiLane(recognized, start, measures, indent),
+		} {
+			if lane != "" {
+				b.WriteString(lane + "\n")
+			}
+		}
+
+		for str := 0; str < 6; str++ {
+			b.WriteString(labels[str] + "|")
+			for measure := first; measure < first+measures; measure++ {
+				from := measure * models.MeasureLength
+				b.WriteString(tab.Content[str][from:from+models.MeasureLength] + "|")
+			}
+			b.WriteString("\n")
+		}
+
+		if lyrics := asciiLane(tab.Lyrics, start, measures, indent); lyrics != "" {
+			b.WriteString(lyrics + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// reversed returns the tuning from the lowest string to the highest, the
+// order tunings are usually written in
+func reversed(tuning [6]string) []string {
+	names := make([]string, 0, 6)
+	for i := 5; i >= 0; i-- {
+		names = append(names, tuning[i])
+	}
+	return names
+}
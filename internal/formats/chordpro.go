@@ -0,0 +1,106 @@
+// internal/formats/chordpro.go
+package formats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// chordProMeasuresPerLine is how many measures make up one lead sheet line
+const chordProMeasuresPerLine = 4
+
+// laneEvent is a chord and/or syllable anchored to the same column
+type laneEvent struct {
+	Position int
+	Chord    string
+	Lyric    string
+}
+
+// laneEvents merges the chord and lyric lanes between start and end
+func laneEvents(tab *models.Tab, start, end int) []laneEvent {
+	byPosition := make(map[int]*laneEvent)
+	get := func(pos int) *laneEvent {
+		if e, ok := byPosition[pos]; ok {
+			return e
+		}
+		e := &laneEvent{Position: pos}
+		byPosition[pos] = e
+		return e
+	}
+
+	for _, a := range tab.Chords {
+		if a.Position >= start && a.Position < end {
+			get(a.Position).Chord = a.Text
+		}
+	}
+	for _, a := range tab.Lyrics {
+		if a.Position >= start && a.Position < end {
+			get(a.Position).Lyric = a.Text
+		}
+	}
+
+	events := make([]laneEvent, 0, len(byPosition))
+	for _, e := range byPosition {
+		events = append(events, *e)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Position < events[j].Position })
+	return events
+}
+
+// chordProLine formats merged lane events as a ChordPro lyrics line. A
+// syllable ending in "-" is joined to the next one, so "Hel-" "lo" reads
+// as "Hello" with the chords placed inside the word.
+func chordProLine(events []laneEvent) string {
+	var b strings.Builder
+	joinNext := true
+	for _, e := range events {
+		if !joinNext {
+			b.WriteString(" ")
+		}
+		if e.Chord != "" {
+			b.WriteString("[" + e.Chord + "]")
+		}
+
+		lyric := e.Lyric
+		joinNext = strings.HasSuffix(lyric, "-") && len(lyric) > 1
+		if joinNext {
+			lyric = strings.TrimSuffix(lyric, "-")
+		}
+		b.WriteString(lyric)
+	}
+	return b.String()
+}
+
+// WriteChordPro writes the chord and lyric lanes of the tab as a ChordPro
+// lead sheet
+func WriteChordPro(w io.Writer, tab *models.Tab) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "{title: %s}\n", tab.Name)
+	if tab.Artist != "" {
+		fmt.Fprintf(&b, "{artist: %s}\n", tab.Artist)
+	}
+	fmt.Fprintf(&b, "{tempo: %d}\n", tempoOrDefault(tab))
+	if tab.Capo > 0 {
+		fmt.Fprintf(&b, "{capo: %d}\n", tab.Capo)
+	}
+	if tab.TimeSignature != "" {
+		fmt.Fprintf(&b, "{time: %s}\n", tab.TimeSignature)
+	}
+	b.WriteString("\n")
+
+	lineLength := chordProMeasuresPerLine * models.MeasureLength
+	for start := 0; start < tab.GetTotalLength(); start += lineLength {
+		events := laneEvents(tab, start, start+lineLength)
+		if len(events) == 0 {
+			continue
+		}
+		b.WriteString(chordProLine(events) + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,196 @@
+// internal/formats/formats.go
+package formats
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Cod-e-Codes/tuitar/internal/midi"
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+	"github.com/Cod-e-Codes/tuitar/internal/render"
+)
+
+// Each tab column is a sixteenth note, so a quarter note spans four columns
+const columnsPerQuarter = 4
+
+// standardDurations lists the note lengths (in columns) that can be written
+// without ties, longest first
+var standardDurations = []int{16, 12, 8, 6, 4, 3, 2, 1}
+
+// splitDuration breaks a length in columns into standard note lengths
+func splitDuration(columns int) []int {
+	var parts []int
+	for _, d := range standardDurations {
+		for columns >= d {
+			parts = append(parts, d)
+			columns -= d
+		}
+	}
+	return parts
+}
+
+// measureEvent is a group of notes starting on the same column of a measure
+type measureEvent struct {
+	Column   int // Column offset within the measure
+	Duration int // Columns until the next event or the end of the measure
+	Notes    []models.Note
+	Rest     bool
+}
+
+// measureEvents groups the notes of one measure into chords and rests
+func measureEvents(tab *models.Tab, measure int) []measureEvent {
+	start := measure * models.MeasureLength
+	end := start + models.MeasureLength
+
+	var events []measureEvent
+	for _, note := range tab.Notes() {
+		if note.Position < start || note.Position >= end {
+			continue
+		}
+		column := note.Position - start
+		if len(events) > 0 && events[len(events)-1].Column == column {
+			events[len(events)-1].Notes = append(events[len(events)-1].Notes, note)
+			continue
+		}
+		events = append(events, measureEvent{Column: column, Notes: []models.Note{note}})
+	}
+
+	var result []measureEvent
+	cursor := 0
+	for i, event := range events {
+		if event.Column > cursor {
+			result = append(result, measureEvent{Column: cursor, Duration: event.Column - cursor, Rest: true})
+		}
+		next := models.MeasureLength
+		if i+1 < len(events) {
+			next = events[i+1].Column
+		}
+		event.Duration = next - event.Column
+		result = append(result, event)
+		cursor = next
+	}
+	if cursor < models.MeasureLength {
+		result = append(result, measureEvent{Column: cursor, Duration: models.MeasureLength - cursor, Rest: true})
+	}
+
+	return result
+}
+
+// parseTimeSignature splits a signature such as "3/4", defaulting to 4/4
+func parseTimeSignature(signature string) (beats, beatType int) {
+	if _, err := fmt.Sscanf(signature, "%d/%d", &beats, &beatType); err != nil || beats <= 0 || beatType <= 0 {
+		return 4, 4
+	}
+	return beats, beatType
+}
+
+// measureMeter returns the time signature to write for the tab's measures,
+// which always hold a whole note: the tab's own when it adds up to one, and
+// otherwise a whole note in the tab's beat unit, such as 4/4 for a 3/4 tab,
+// with fits false
+func measureMeter(tab *models.Tab) (beats, beatType int, fits bool) {
+	beats, beatType = parseTimeSignature(tab.TimeSignature)
+	if beats*16 == models.MeasureLength*beatType {
+		return beats, beatType, true
+	}
+	return models.MeasureLength * beatType / 16, beatType, false
+}
+
+// tempoOrDefault returns the tab tempo, falling back to 120 BPM
+func tempoOrDefault(tab *models.Tab) int {
+	if tab.Tempo <= 0 {
+		return 120
+	}
+	return tab.Tempo
+}
+
+// endingStarts reports whether a volta bracket begins at the measure
+func endingStarts(tab *models.Tab, measure int) bool {
+	ending := tab.FormAt(measure).Ending
+	return ending > 0 && (measure == 0 || tab.FormAt(measure-1).Ending != ending)
+}
+
+// endingStops reports whether a volta bracket ends with the measure
+func endingStops(tab *models.Tab, measure int) bool {
+	ending := tab.FormAt(measure).Ending
+	return ending > 0 && (measure == tab.GetMeasureCount()-1 || tab.FormAt(measure+1).Ending != ending)
+}
+
+// exportWriter returns the writer for a file extension
+func exportWriter(ext string) (func(io.Writer, *models.Tab) error, bool) {
+	switch strings.ToLower(ext) {
+	case ".musicxml", ".xml":
+		return WriteMusicXML, true
+	case ".ly":
+		return WriteLilyPond, true
+	case ".atex", ".alphatex":
+		return WriteAlphaTex, true
+	case ".cho", ".chopro", ".chordpro":
+		return WriteChordPro, true
+	case ".txt", ".tab":
+		return WriteASCII, true
+	case ".mid", ".midi":
+		return midi.WriteSMF, true
+	case ".svg":
+		return func(w io.Writer, tab *models.Tab) error {
+			return render.WriteSVG(w, tab, render.DefaultOptions())
+		}, true
+	case ".html", ".htm":
+		return func(w io.Writer, tab *models.Tab) error {
+			return render.WriteHTML(w, tab, render.DefaultOptions())
+		}, true
+	}
+	return nil, false
+}
+
+// ExportFile writes the tab to path, choosing the format from the file
+// extension. The tab is written to a temporary file next to path that
+// replaces it only once complete, so a failed export leaves an existing
+// file as it was.
+func ExportFile(tab *models.Tab, path string) error {
+	path = filepath.Clean(path)
+	write, ok := exportWriter(filepath.Ext(path))
+	if !ok {
+		return fmt.Errorf("unsupported export format: %s", filepath.Ext(path))
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	err = write(file, tab)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		// CreateTemp makes the file private; exports get the usual mode
+		err = os.Chmod(file.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+	}
+	return err
+}
+
+// ImportFile reads a tab from path, choosing the format from the file extension
+func ImportFile(path string) (*models.Tab, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".musicxml", ".xml":
+		file, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return ReadMusicXML(file)
+	case ".mxl":
+		return ReadCompressedMusicXML(path)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s", filepath.Ext(path))
+	}
+}
@@ -0,0 +1,48 @@
+package formats
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+func TestExportFileUnsupported(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.md")
+	if err := os.WriteFile(path, []byte("my notes"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ExportFile(models.NewTestTab("Lesson 1"), path); err == nil {
+		t.Error("Expected an error for an unsupported extension")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "my notes" {
+		t.Errorf("Expected the file left unchanged, got %q (%v)", data, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expected no temporary files left, got %d entries", len(entries))
+	}
+}
+
+func TestExportFileReplaces(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "lesson.txt")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ExportFile(models.NewTestTab("Lesson 1"), path); err != nil {
+		t.Fatalf("ExportFile failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "e|") {
+		t.Errorf("Expected the tab written over the old file, got %q (%v)", data, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expected no temporary files left, got %d entries", len(entries))
+	}
+}
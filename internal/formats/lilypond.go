@@ -0,0 +1,177 @@
+// internal/formats/lilypond.go
+package formats
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// lilyDurations maps a length in columns to a LilyPond duration
+var lilyDurations = map[int]string{
+	16: "1",
+	12: "2.",
+	8:  "2",
+	6:  "4.",
+	4:  "4",
+	3:  "8.",
+	2:  "8",
+	1:  "16",
+}
+
+// lilyPitch spells a MIDI note number in LilyPond absolute notation
+func lilyPitch(midi int) string {
+	name := strings.ToLower(models.NoteNames[midi%12])
+	name = strings.Replace(name, "#", "is", 1)
+
+	octave := midi/12 - 4 // c (no octave marks) is MIDI 48
+	switch {
+	case octave > 0:
+		name += strings.Repeat("'", octave)
+	case octave < 0:
+		name += strings.Repeat(",", -octave)
+	}
+	return name
+}
+
+// lilyString quotes a string for use in a LilyPond header
+func lilyString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// lilyFormStart writes the repeat signs, volta bracket and segno/coda
+// marks that open a measure
+func lilyFormStart(tab *models.Tab, measure int) string {
+	form := tab.FormAt(measure)
+	var b strings.Builder
+
+	var commands []string
+	if form.RepeatStart {
+		commands = append(commands, "start-repeat")
+	}
+	if endingStarts(tab, measure) {
+		commands = append(commands, fmt.Sprintf("(volta \"%d.\")", form.Ending))
+	}
+	if len(commands) > 0 {
+		fmt.Fprintf(&b, "  \\set Score.repeatCommands = #'(%s)\n", strings.Join(commands, " "))
+	}
+
+	if form.Segno {
+		b.WriteString("  \\mark \\markup { \\musicglyph \"scripts.segno\" }\n")
+	}
+	if form.Coda {
+		b.WriteString("  \\mark \\markup { \\musicglyph \"scripts.coda\" }\n")
+	}
+	return b.String()
+}
+
+// lilyFormEnd writes the markings that close a measure: jumps, Fine,
+// the end of a volta bracket and backward repeat signs
+func lilyFormEnd(tab *models.Tab, measure int) string {
+	form := tab.FormAt(measure)
+	var b strings.Builder
+
+	var words []string
+	if form.ToCoda {
+		words = append(words, "To Coda")
+	}
+	if form.Fine {
+		words = append(words, "Fine")
+	}
+	if form.Jump != models.JumpNone {
+		words = append(words, string(form.Jump))
+	}
+	for _, text := range words {
+		fmt.Fprintf(&b, "  \\tweak self-alignment-X #RIGHT \\mark \\markup { \\italic %s }\n", lilyString(text))
+	}
+
+	var commands []string
+	if endingStops(tab, measure) {
+		commands = append(commands, "(volta #f)")
+	}
+	if form.RepeatEnd > 0 {
+		commands = append(commands, "end-repeat")
+	}
+	if len(commands) > 0 {
+		fmt.Fprintf(&b, "  \\set Score.repeatCommands = #'(%s)\n", strings.Join(commands, " "))
+	}
+	return b.String()
+}
+
+// WriteLilyPond writes the tab as a LilyPond score with a standard staff
+// above a TabStaff, ready to be engraved with the lilypond command
+func WriteLilyPond(w io.Writer, tab *models.Tab) error {
+	beats, beatType := parseTimeSignature(tab.TimeSignature)
+	// Frets count from the capo, so the TabStaff is tuned to the capo too
+	openStrings := tab.CapoStringMIDI()
+
+	var b strings.Builder
+	b.WriteString("\\version \"2.24.0\"\n\n")
+	b.WriteString("\\header {\n")
+	fmt.Fprintf(&b, "  title = %s\n", lilyString(tab.Name))
+	if tab.Artist != "" {
+		fmt.Fprintf(&b, "  composer = %s\n", lilyString(tab.Artist))
+	}
+	if tab.Capo > 0 {
+		fmt.Fprintf(&b, "  subtitle = \"Capo %d\"\n", tab.Capo)
+	}
+	b.WriteString("  tagline = ##f\n}\n\n")
+
+	b.WriteString("music = {\n")
+	fmt.Fprintf(&b, "  \\tempo 4 = %d\n", tempoOrDefault(tab))
+	fmt.Fprintf(&b, "  \\time %d/%d\n", beats, beatType)
+	if beats*16/beatType != models.MeasureLength {
+		// Keep bar lines on tuitar's measure boundaries even when the
+		// time signature does not add up to a whole note
+		b.WriteString("  \\set Timing.measureLength = #(ly:make-moment 1/1)\n")
+	}
+
+	for measure := 0; measure < tab.GetMeasureCount(); measure++ {
+		b.WriteString(lilyFormStart(tab, measure))
+
+		var tokens []string
+		for _, event := range measureEvents(tab, measure) {
+			parts := splitDuration(event.Duration)
+			if event.Rest {
+				for _, d := range parts {
+					tokens = append(tokens, "r"+lilyDurations[d])
+				}
+				continue
+			}
+
+			var pitches []string
+			for _, note := range event.Notes {
+				pitches = append(pitches, fmt.Sprintf("%s\\%d", lilyPitch(openStrings[note.String]+note.Fret), note.String+1))
+			}
+			tokens = append(tokens, "<"+strings.Join(pitches, " ")+">"+lilyDurations[parts[0]])
+			for _, d := range parts[1:] {
+				tokens = append(tokens, "r"+lilyDurations[d])
+			}
+		}
+		fmt.Fprintf(&b, "  %s | %% %d\n", strings.Join(tokens, " "), measure+1)
+		b.WriteString(lilyFormEnd(tab, measure))
+	}
+	if tab.FormAt(tab.GetMeasureCount()-1).RepeatEnd == 0 {
+		b.WriteString("  \\bar \"|.\"\n")
+	}
+	b.WriteString("}\n\n")
+
+	var tuning []string
+	for i := 5; i >= 0; i-- {
+		tuning = append(tuning, lilyPitch(openStrings[i]))
+	}
+
+	b.WriteString("\\score {\n")
+	b.WriteString("  \\new StaffGroup <<\n")
+	b.WriteString("    \\new Staff \\with { \\omit StringNumber } { \\clef \"treble_8\" \\music }\n")
+	fmt.Fprintf(&b, "    \\new TabStaff \\with { stringTunings = \\stringTuning <%s> } { \\music }\n", strings.Join(tuning, " "))
+	b.WriteString("  >>\n")
+	b.WriteString("  \\layout { }\n")
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,844 @@
+// internal/formats/musicxml.go
+package formats
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+const musicXMLHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<!DOCTYPE score-partwise PUBLIC "-//Recordare//DTD MusicXML 4.0 Partwise//EN" "http://www.musicxml.org/dtds/partwise.dtd">
+`
+
+// MusicXML document structure (partwise). Only the elements tuitar reads
+// or writes are modelled here.
+type xmlScore struct {
+	XMLName        xml.Name           `xml:"score-partwise"`
+	Version        string             `xml:"version,attr,omitempty"`
+	Work           *xmlWork           `xml:"work,omitempty"`
+	MovementTitle  string             `xml:"movement-title,omitempty"`
+	Identification *xmlIdentification `xml:"identification,omitempty"`
+	PartList       xmlPartList        `xml:"part-list"`
+	Parts          []xmlPart          `xml:"part"`
+}
+
+type xmlWork struct {
+	Title string `xml:"work-title,omitempty"`
+}
+
+type xmlIdentification struct {
+	Creators      []xmlCreator      `xml:"creator"`
+	Miscellaneous *xmlMiscellaneous `xml:"miscellaneous,omitempty"`
+}
+
+type xmlMiscellaneous struct {
+	Fields []xmlMiscField `xml:"miscellaneous-field"`
+}
+
+type xmlMiscField struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// meterField names the miscellaneous field holding the tab's own time
+// signature, when the measures are written in another that fits them
+const meterField = "tuitar-time-signature"
+
+type xmlCreator struct {
+	Type string `xml:"type,attr,omitempty"`
+	Name string `xml:",chardata"`
+}
+
+type xmlPartList struct {
+	ScoreParts []xmlScorePart `xml:"score-part"`
+}
+
+type xmlScorePart struct {
+	ID   string `xml:"id,attr"`
+	Name string `xml:"part-name"`
+}
+
+type xmlPart struct {
+	ID       string       `xml:"id,attr"`
+	Measures []xmlMeasure `xml:"measure"`
+}
+
+type xmlMeasure struct {
+	Number     string         `xml:"number,attr"`
+	Attributes *xmlAttributes `xml:"attributes,omitempty"`
+	Directions []xmlDirection `xml:"direction"`
+	Notes      []xmlNote      `xml:"note"`
+	Barlines   []xmlBarline   `xml:"barline"`
+
+	// Inner holds the raw measure body when reading, so elements can be
+	// processed in document order
+	Inner []byte `xml:",innerxml"`
+}
+
+type xmlAttributes struct {
+	Divisions    int               `xml:"divisions,omitempty"`
+	Time         *xmlTime          `xml:"time,omitempty"`
+	Staves       int               `xml:"staves,omitempty"`
+	Clefs        []xmlClef         `xml:"clef"`
+	StaffDetails []xmlStaffDetails `xml:"staff-details"`
+}
+
+type xmlTime struct {
+	Beats    string `xml:"beats"`
+	BeatType string `xml:"beat-type"`
+}
+
+type xmlClef struct {
+	Number int    `xml:"number,attr,omitempty"`
+	Sign   string `xml:"sign"`
+	Line   int    `xml:"line,omitempty"`
+}
+
+type xmlStaffDetails struct {
+	Number     int              `xml:"number,attr,omitempty"`
+	StaffLines int              `xml:"staff-lines,omitempty"`
+	Tunings    []xmlStaffTuning `xml:"staff-tuning"`
+	Capo       int              `xml:"capo,omitempty"`
+}
+
+type xmlStaffTuning struct {
+	Line   int     `xml:"line,attr"`
+	Step   string  `xml:"tuning-step"`
+	Alter  float64 `xml:"tuning-alter,omitempty"`
+	Octave int     `xml:"tuning-octave"`
+}
+
+type xmlDirection struct {
+	Placement     string            `xml:"placement,attr,omitempty"`
+	DirectionType *xmlDirectionType `xml:"direction-type,omitempty"`
+	Sound         *xmlSound         `xml:"sound,omitempty"`
+}
+
+type xmlDirectionType struct {
+	Segno     *struct{}     `xml:"segno,omitempty"`
+	Coda      *struct{}     `xml:"coda,omitempty"`
+	Words     string        `xml:"words,omitempty"`
+	Metronome *xmlMetronome `xml:"metronome,omitempty"`
+}
+
+type xmlMetronome struct {
+	BeatUnit  string  `xml:"beat-unit"`
+	PerMinute float64 `xml:"per-minute"`
+}
+
+type xmlSound struct {
+	Tempo    float64 `xml:"tempo,attr,omitempty"`
+	DaCapo   string  `xml:"dacapo,attr,omitempty"`
+	DalSegno string  `xml:"dalsegno,attr,omitempty"`
+	Segno    string  `xml:"segno,attr,omitempty"`
+	Coda     string  `xml:"coda,attr,omitempty"`
+	ToCoda   string  `xml:"tocoda,attr,omitempty"`
+	Fine     string  `xml:"fine,attr,omitempty"`
+}
+
+type xmlBarline struct {
+	Location string     `xml:"location,attr,omitempty"`
+	BarStyle string     `xml:"bar-style,omitempty"`
+	Ending   *xmlEnding `xml:"ending,omitempty"`
+	Repeat   *xmlRepeat `xml:"repeat,omitempty"`
+}
+
+type xmlEnding struct {
+	Number string `xml:"number,attr"`
+	Type   string `xml:"type,attr"` // start, stop or discontinue
+	Text   string `xml:",chardata"`
+}
+
+type xmlRepeat struct {
+	Direction string `xml:"direction,attr"` // forward or backward
+	Times     int    `xml:"times,attr,omitempty"`
+}
+
+type xmlNote struct {
+	Grace     *struct{}     `xml:"grace,omitempty"`
+	Chord     *struct{}     `xml:"chord,omitempty"`
+	Pitch     *xmlPitch     `xml:"pitch,omitempty"`
+	Rest      *struct{}     `xml:"rest,omitempty"`
+	Duration  int           `xml:"duration,omitempty"`
+	Voice     string        `xml:"voice,omitempty"`
+	Type      string        `xml:"type,omitempty"`
+	Dot       *struct{}     `xml:"dot,omitempty"`
+	Staff     int           `xml:"staff,omitempty"`
+	Notations *xmlNotations `xml:"notations,omitempty"`
+}
+
+type xmlPitch struct {
+	Step   string  `xml:"step"`
+	Alter  float64 `xml:"alter,omitempty"`
+	Octave int     `xml:"octave"`
+}
+
+type xmlNotations struct {
+	Technical *xmlTechnical `xml:"technical,omitempty"`
+}
+
+type xmlTechnical struct {
+	String int  `xml:"string"`
+	Fret   *int `xml:"fret"`
+}
+
+type xmlBackupForward struct {
+	Duration int `xml:"duration"`
+}
+
+// noteTypes maps a length in columns to its MusicXML note type and dot
+var noteTypes = map[int]struct {
+	Type   string
+	Dotted bool
+}{
+	16: {"whole", false},
+	12: {"half", true},
+	8:  {"half", false},
+	6:  {"quarter", true},
+	4:  {"quarter", false},
+	3:  {"eighth", true},
+	2:  {"eighth", false},
+	1:  {"16th", false},
+}
+
+// midiToPitch spells a MIDI note number using sharps
+func midiToPitch(midi int) xmlPitch {
+	name := models.NoteNames[midi%12]
+	pitch := xmlPitch{Step: name[:1], Octave: midi/12 - 1}
+	if len(name) > 1 {
+		pitch.Alter = 1
+	}
+	return pitch
+}
+
+// pitchToMIDI converts a MusicXML step, alteration and octave to a MIDI note number
+func pitchToMIDI(step string, alter float64, octave int) (int, bool) {
+	pc, ok := models.ParsePitchClass(step)
+	if !ok {
+		return 0, false
+	}
+	return (octave+1)*12 + pc + int(math.Round(alter)), true
+}
+
+// restNote builds a rest of the given length in columns
+func restNote(columns int) xmlNote {
+	info := noteTypes[columns]
+	note := xmlNote{Rest: &struct{}{}, Duration: columns, Voice: "1", Type: info.Type}
+	if info.Dotted {
+		note.Dot = &struct{}{}
+	}
+	return note
+}
+
+// WriteMusicXML writes the tab as a partwise MusicXML document with a
+// single six-string TAB staff
+func WriteMusicXML(w io.Writer, tab *models.Tab) error {
+	beats, beatType, fits := measureMeter(tab)
+	openStrings := tab.OpenStringMIDI()
+	capoStrings := tab.CapoStringMIDI()
+
+	score := xmlScore{
+		Version:  "4.0",
+		Work:     &xmlWork{Title: tab.Name},
+		PartList: xmlPartList{ScoreParts: []xmlScorePart{{ID: "P1", Name: "Guitar"}}},
+	}
+	if tab.Artist != "" || !fits {
+		score.Identification = &xmlIdentification{}
+	}
+	if tab.Artist != "" {
+		score.Identification.Creators = []xmlCreator{{Type: "composer", Name: tab.Artist}}
+	}
+	if !fits {
+		// Every measure holds a whole note, so the time written is one that
+		// adds up to it; the tab's own is kept here for the import
+		score.Identification.Miscellaneous = &xmlMiscellaneous{
+			Fields: []xmlMiscField{{Name: meterField, Value: tab.TimeSignature}},
+		}
+	}
+
+	staffDetails := xmlStaffDetails{StaffLines: 6, Capo: tab.Capo}
+	for i := 5; i >= 0; i-- {
+		pitch := midiToPitch(openStrings[i])
+		staffDetails.Tunings = append(staffDetails.Tunings, xmlStaffTuning{
+			Line:   6 - i,
+			Step:   pitch.Step,
+			Alter:  pitch.Alter,
+			Octave: pitch.Octave,
+		})
+	}
+
+	part := xmlPart{ID: "P1"}
+	for measure := 0; measure < tab.GetMeasureCount(); measure++ {
+		xm := xmlMeasure{Number: strconv.Itoa(measure + 1)}
+
+		if measure == 0 {
+			xm.Attributes = &xmlAttributes{
+				Divisions:    columnsPerQuarter,
+				Time:         &xmlTime{Beats: strconv.Itoa(beats), BeatType: strconv.Itoa(beatType)},
+				Clefs:        []xmlClef{{Sign: "TAB", Line: 5}},
+				StaffDetails: []xmlStaffDetails{staffDetails},
+			}
+			tempo := tempoOrDefault(tab)
+			xm.Directions = append(xm.Directions, xmlDirection{
+				Placement: "above",
+				DirectionType: &xmlDirectionType{
+					Metronome: &xmlMetronome{BeatUnit: "quarter", PerMinute: float64(tempo)},
+				},
+				Sound: &xmlSound{Tempo: float64(tempo)},
+			})
+		}
+
+		xm.Directions = append(xm.Directions, formDirections(tab.FormAt(measure))...)
+		xm.Barlines = formBarlines(tab, measure)
+
+		for _, event := range measureEvents(tab, measure) {
+			parts := splitDuration(event.Duration)
+			if event.Rest {
+				for _, d := range parts {
+					xm.Notes = append(xm.Notes, restNote(d))
+				}
+				continue
+			}
+
+			// The note sounds for the longest standard length that fits;
+			// any remainder is written as rests
+			info := noteTypes[parts[0]]
+			for i, note := range event.Notes {
+				fret := note.Fret
+				pitch := midiToPitch(capoStrings[note.String] + fret)
+				xn := xmlNote{
+					Pitch:     &pitch,
+					Duration:  parts[0],
+					Voice:     "1",
+					Type:      info.Type,
+					Notations: &xmlNotations{Technical: &xmlTechnical{String: note.String + 1, Fret: &fret}},
+				}
+				if info.Dotted {
+					xn.Dot = &struct{}{}
+				}
+				if i > 0 {
+					xn.Chord = &struct{}{}
+				}
+				xm.Notes = append(xm.Notes, xn)
+			}
+			for _, d := range parts[1:] {
+				xm.Notes = append(xm.Notes, restNote(d))
+			}
+		}
+
+		part.Measures = append(part.Measures, xm)
+	}
+	score.Parts = []xmlPart{part}
+
+	data, err := xml.MarshalIndent(score, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, musicXMLHeader); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// formDirections writes the navigation markings of a measure as
+// directions, with sound attributes so players can follow them
+func formDirections(form models.MeasureForm) []xmlDirection {
+	var directions []xmlDirection
+	add := func(dt xmlDirectionType, sound xmlSound) {
+		directions = append(directions, xmlDirection{Placement: "above", DirectionType: &dt, Sound: &sound})
+	}
+
+	if form.Segno {
+		add(xmlDirectionType{Segno: &struct{}{}}, xmlSound{Segno: "segno"})
+	}
+	if form.Coda {
+		add(xmlDirectionType{Coda: &struct{}{}}, xmlSound{Coda: "coda"})
+	}
+	if form.ToCoda {
+		add(xmlDirectionType{Words: "To Coda"}, xmlSound{ToCoda: "coda"})
+	}
+	if form.Fine {
+		add(xmlDirectionType{Words: "Fine"}, xmlSound{Fine: "yes"})
+	}
+	switch {
+	case strings.HasPrefix(string(form.Jump), "D.C."):
+		add(xmlDirectionType{Words: string(form.Jump)}, xmlSound{DaCapo: "yes"})
+	case strings.HasPrefix(string(form.Jump), "D.S."):
+		add(xmlDirectionType{Words: string(form.Jump)}, xmlSound{DalSegno: "segno"})
+	}
+	return directions
+}
+
+// formBarlines writes the repeat signs and volta brackets of a measure
+func formBarlines(tab *models.Tab, measure int) []xmlBarline {
+	form := tab.FormAt(measure)
+	var barlines []xmlBarline
+
+	if form.RepeatStart || endingStarts(tab, measure) {
+		left := xmlBarline{Location: "left"}
+		if form.RepeatStart {
+			left.BarStyle = "heavy-light"
+			left.Repeat = &xmlRepeat{Direction: "forward"}
+		}
+		if endingStarts(tab, measure) {
+			number := strconv.Itoa(form.Ending)
+			left.Ending = &xmlEnding{Number: number, Type: "start", Text: number + "."}
+		}
+		barlines = append(barlines, left)
+	}
+
+	if form.RepeatEnd > 0 || endingStops(tab, measure) {
+		right := xmlBarline{Location: "right"}
+		if endingStops(tab, measure) {
+			// A bracket closed by a repeat sign gets a downward hook
+			kind := "discontinue"
+			if form.RepeatEnd > 0 {
+				kind = "stop"
+			}
+			right.Ending = &xmlEnding{Number: strconv.Itoa(form.Ending), Type: kind}
+		}
+		if form.RepeatEnd > 0 {
+			right.BarStyle = "light-heavy"
+			right.Repeat = &xmlRepeat{Direction: "backward"}
+			if form.RepeatEnd > 2 {
+				right.Repeat.Times = form.RepeatEnd
+			}
+		}
+		barlines = append(barlines, right)
+	}
+
+	return barlines
+}
+
+// musicXMLImport tracks state while reading a part into a tab
+type musicXMLImport struct {
+	tab       *models.Tab
+	divisions int
+	tabStaff  int // Staff number of the TAB staff, 0 if the part has a single staff
+	strings   int // Number of strings on the TAB staff
+	haveTempo bool
+	haveTime  bool
+
+	endingStart int // Measure where the open volta bracket started, -1 if none
+}
+
+// ReadMusicXML reads a partwise MusicXML document and converts the first
+// part containing tablature into a tab
+func ReadMusicXML(r io.Reader) (*models.Tab, error) {
+	var score xmlScore
+	if err := xml.NewDecoder(r).Decode(&score); err != nil {
+		return nil, fmt.Errorf("invalid MusicXML: %w", err)
+	}
+	if len(score.Parts) == 0 {
+		return nil, fmt.Errorf("MusicXML file has no parts")
+	}
+
+	name := score.MovementTitle
+	if score.Work != nil && score.Work.Title != "" {
+		name = score.Work.Title
+	}
+	if name == "" {
+		name = "Imported Tab"
+	}
+
+	part := score.Parts[0]
+	for _, p := range score.Parts {
+		if partHasTablature(p) {
+			part = p
+			break
+		}
+	}
+
+	imp := &musicXMLImport{tab: models.NewEmptyTab(name), divisions: 1, strings: 6, endingStart: -1}
+	if score.Identification != nil {
+		for _, creator := range score.Identification.Creators {
+			if creator.Type == "composer" || creator.Type == "artist" || imp.tab.Artist == "" {
+				imp.tab.Artist = strings.TrimSpace(creator.Name)
+			}
+		}
+		if misc := score.Identification.Miscellaneous; misc != nil {
+			for _, field := range misc.Fields {
+				if field.Name == meterField {
+					beats, beatType := parseTimeSignature(strings.TrimSpace(field.Value))
+					imp.tab.TimeSignature = fmt.Sprintf("%d/%d", beats, beatType)
+					imp.haveTime = true
+				}
+			}
+		}
+	}
+
+	measures := len(part.Measures)
+	if measures < 1 {
+		measures = 1
+	}
+	for imp.tab.Measures < measures {
+		imp.tab.AddMeasure()
+	}
+	for imp.tab.Measures > measures {
+		imp.tab.RemoveMeasure()
+	}
+
+	for i, measure := range part.Measures {
+		if err := imp.readMeasure(i, measure.Inner); err != nil {
+			return nil, fmt.Errorf("measure %s: %w", measure.Number, err)
+		}
+	}
+
+	imp.tab.UpdatedAt = time.Now()
+	return imp.tab, nil
+}
+
+// ReadCompressedMusicXML reads a compressed (.mxl) MusicXML archive
+func ReadCompressedMusicXML(filename string) (*models.Tab, error) {
+	archive, err := zip.OpenReader(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	var rootFile string
+	for _, f := range archive.File {
+		if f.Name != "META-INF/container.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		var container struct {
+			RootFiles []struct {
+				FullPath string `xml:"full-path,attr"`
+			} `xml:"rootfiles>rootfile"`
+		}
+		err = xml.NewDecoder(rc).Decode(&container)
+		rc.Close()
+		if err == nil && len(container.RootFiles) > 0 {
+			rootFile = container.RootFiles[0].FullPath
+		}
+	}
+
+	for _, f := range archive.File {
+		isScore := f.Name == rootFile
+		if rootFile == "" {
+			ext := path.Ext(f.Name)
+			isScore = !strings.HasPrefix(f.Name, "META-INF/") && (ext == ".xml" || ext == ".musicxml")
+		}
+		if !isScore {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return ReadMusicXML(rc)
+	}
+
+	return nil, fmt.Errorf("no score found in %s", filename)
+}
+
+// partHasTablature reports whether a part declares a TAB clef or carries
+// string and fret information on its notes
+func partHasTablature(part xmlPart) bool {
+	for _, measure := range part.Measures {
+		inner := measure.Inner
+		if bytes.Contains(inner, []byte("<sign>TAB</sign>")) || bytes.Contains(inner, []byte("<fret>")) {
+			return true
+		}
+	}
+	return false
+}
+
+// readMeasure processes the elements of one measure in document order
+func (imp *musicXMLImport) readMeasure(index int, inner []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(inner))
+	cursor := 0    // Current time in divisions
+	lastOnset := 0 // Onset of the previous non-chord note
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "attributes":
+			var attrs xmlAttributes
+			if err := decoder.DecodeElement(&attrs, &start); err != nil {
+				return err
+			}
+			imp.applyAttributes(attrs)
+
+		case "direction":
+			var direction xmlDirection
+			if err := decoder.DecodeElement(&direction, &start); err != nil {
+				return err
+			}
+			imp.applyDirection(index, direction)
+
+		case "sound":
+			var sound xmlSound
+			if err := decoder.DecodeElement(&sound, &start); err != nil {
+				return err
+			}
+			imp.applyTempo(sound.Tempo)
+			imp.applySound(index, sound)
+
+		case "barline":
+			var barline xmlBarline
+			if err := decoder.DecodeElement(&barline, &start); err != nil {
+				return err
+			}
+			imp.applyBarline(index, barline)
+
+		case "backup", "forward":
+			var bf xmlBackupForward
+			if err := decoder.DecodeElement(&bf, &start); err != nil {
+				return err
+			}
+			if start.Name.Local == "backup" {
+				cursor -= bf.Duration
+				if cursor < 0 {
+					cursor = 0
+				}
+			} else {
+				cursor += bf.Duration
+			}
+
+		case "note":
+			var note xmlNote
+			if err := decoder.DecodeElement(&note, &start); err != nil {
+				return err
+			}
+			if note.Grace != nil {
+				continue
+			}
+
+			onset := cursor
+			if note.Chord != nil {
+				onset = lastOnset
+			} else {
+				lastOnset = cursor
+				cursor += note.Duration
+			}
+			imp.placeNote(index, onset, note)
+		}
+	}
+}
+
+// applyAttributes records divisions, time signature and TAB staff tuning
+func (imp *musicXMLImport) applyAttributes(attrs xmlAttributes) {
+	if attrs.Divisions > 0 {
+		imp.divisions = attrs.Divisions
+	}
+
+	if attrs.Time != nil && !imp.haveTime {
+		beats, errBeats := strconv.Atoi(strings.TrimSpace(attrs.Time.Beats))
+		beatType, errType := strconv.Atoi(strings.TrimSpace(attrs.Time.BeatType))
+		if errBeats == nil && errType == nil {
+			imp.tab.TimeSignature = fmt.Sprintf("%d/%d", beats, beatType)
+			imp.haveTime = true
+		}
+	}
+
+	for _, clef := range attrs.Clefs {
+		if clef.Sign == "TAB" {
+			imp.tabStaff = clef.Number
+		}
+	}
+
+	for _, details := range attrs.StaffDetails {
+		if len(details.Tunings) == 0 {
+			continue
+		}
+		if imp.tabStaff != 0 && details.Number != 0 && details.Number != imp.tabStaff {
+			continue
+		}
+		if details.StaffLines > 0 {
+			imp.strings = details.StaffLines
+		}
+		if details.Capo > 0 && details.Capo <= models.MaxFret {
+			imp.tab.Capo = details.Capo
+		}
+		for _, tuning := range details.Tunings {
+			idx := imp.strings - tuning.Line
+			if idx < 0 || idx >= 6 {
+				continue
+			}
+			midi, ok := pitchToMIDI(tuning.Step, tuning.Alter, tuning.Octave)
+			if !ok {
+				continue
+			}
+			name := models.NoteNames[(midi%12+12)%12]
+			if idx == 0 {
+				name = strings.ToLower(name)
+			}
+			imp.tab.Tuning[idx] = name
+		}
+	}
+}
+
+// applyDirection picks up a metronome mark or tempo sound, and navigation
+// markings written as symbols, words or sound attributes
+func (imp *musicXMLImport) applyDirection(measure int, direction xmlDirection) {
+	if direction.Sound != nil {
+		imp.applyTempo(direction.Sound.Tempo)
+		imp.applySound(measure, *direction.Sound)
+	}
+
+	dt := direction.DirectionType
+	if dt == nil {
+		return
+	}
+	if dt.Metronome != nil && dt.Metronome.BeatUnit == "quarter" {
+		imp.applyTempo(dt.Metronome.PerMinute)
+	}
+
+	form := imp.tab.FormAt(measure)
+	if dt.Segno != nil {
+		form.Segno = true
+	}
+	if dt.Coda != nil && (direction.Sound == nil || direction.Sound.ToCoda == "") {
+		form.Coda = true
+	}
+	words := strings.TrimSpace(dt.Words)
+	switch {
+	case strings.EqualFold(words, "Fine"):
+		form.Fine = true
+	case strings.EqualFold(words, "To Coda"):
+		form.ToCoda = true
+	default:
+		for _, jump := range models.Jumps {
+			if jump != models.JumpNone && strings.EqualFold(words, string(jump)) {
+				form.Jump = jump
+			}
+		}
+	}
+	imp.tab.SetForm(form)
+}
+
+// applySound picks up navigation given only as sound attributes
+func (imp *musicXMLImport) applySound(measure int, sound xmlSound) {
+	form := imp.tab.FormAt(measure)
+	if sound.Segno != "" {
+		form.Segno = true
+	}
+	if sound.Coda != "" {
+		form.Coda = true
+	}
+	if sound.ToCoda != "" {
+		form.ToCoda = true
+	}
+	if sound.Fine != "" {
+		form.Fine = true
+	}
+	if sound.DaCapo == "yes" && form.Jump == models.JumpNone {
+		form.Jump = models.JumpDC
+	}
+	if sound.DalSegno != "" && form.Jump == models.JumpNone {
+		form.Jump = models.JumpDS
+	}
+	imp.tab.SetForm(form)
+}
+
+// applyBarline picks up repeat signs and volta brackets
+func (imp *musicXMLImport) applyBarline(measure int, barline xmlBarline) {
+	form := imp.tab.FormAt(measure)
+	if barline.Repeat != nil {
+		switch barline.Repeat.Direction {
+		case "forward":
+			form.RepeatStart = true
+		case "backward":
+			form.RepeatEnd = 2
+			if barline.Repeat.Times > 2 {
+				form.RepeatEnd = barline.Repeat.Times
+			}
+		}
+	}
+	imp.tab.SetForm(form)
+
+	if barline.Ending == nil {
+		return
+	}
+	// "1, 2" means the bracket is played on both passes; keep the first
+	number, err := strconv.Atoi(strings.TrimSpace(strings.Split(barline.Ending.Number, ",")[0]))
+	if err != nil || number < 1 {
+		return
+	}
+	switch barline.Ending.Type {
+	case "start":
+		imp.endingStart = measure
+		form.Ending = number
+		imp.tab.SetForm(form)
+	case "stop", "discontinue":
+		start := imp.endingStart
+		if start < 0 || start > measure {
+			start = measure
+		}
+		for m := start; m <= measure; m++ {
+			f := imp.tab.FormAt(m)
+			f.Ending = number
+			imp.tab.SetForm(f)
+		}
+		imp.endingStart = -1
+	}
+}
+
+// applyTempo sets the tab tempo from the first tempo found in the score
+func (imp *musicXMLImport) applyTempo(tempo float64) {
+	if tempo <= 0 || imp.haveTempo {
+		return
+	}
+	imp.tab.Tempo = int(math.Round(tempo))
+	imp.haveTempo = true
+}
+
+// placeNote writes a tablature note into the tab at its onset
+func (imp *musicXMLImport) placeNote(measure, onset int, note xmlNote) {
+	if note.Rest != nil || note.Notations == nil || note.Notations.Technical == nil {
+		return
+	}
+	if imp.tabStaff != 0 && note.Staff != 0 && note.Staff != imp.tabStaff {
+		return
+	}
+
+	technical := note.Notations.Technical
+	if technical.Fret == nil || technical.String < 1 || technical.String > 6 {
+		return
+	}
+
+	column := onset * columnsPerQuarter / imp.divisions
+	if column >= models.MeasureLength {
+		return
+	}
+
+	pos := measure*models.MeasureLength + column
+	str := technical.String - 1
+	if !imp.tab.SetFret(str, pos, *technical.Fret) {
+		// A two-digit fret on the last column is shifted left to fit
+		imp.tab.SetFret(str, pos-1, *technical.Fret)
+	}
+}
@@ -0,0 +1,150 @@
+package formats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+func TestMusicXMLRoundTrip(t *testing.T) {
+	tab := models.NewTestTab("Round Trip")
+	tab.Artist = "Tester"
+	tab.Tempo = 96
+	tab.TimeSignature = "3/4"
+	tab.Tuning = [6]string{"d", "A", "F#", "D", "A", "D"}
+	tab.Capo = 2
+	tab.SetFret(5, 20, 12)
+
+	var buf bytes.Buffer
+	if err := WriteMusicXML(&buf, tab); err != nil {
+		t.Fatalf("WriteMusicXML failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "<sign>TAB</sign>") {
+		t.Error("Expected a TAB clef in the output")
+	}
+
+	imported, err := ReadMusicXML(&buf)
+	if err != nil {
+		t.Fatalf("ReadMusicXML failed: %v", err)
+	}
+
+	if imported.Name != tab.Name || imported.Artist != tab.Artist {
+		t.Errorf("Expected metadata %q/%q, got %q/%q", tab.Name, tab.Artist, imported.Name, imported.Artist)
+	}
+	if imported.Tempo != 96 {
+		t.Errorf("Expected tempo 96, got %d", imported.Tempo)
+	}
+	if imported.TimeSignature != "3/4" {
+		t.Errorf("Expected time signature 3/4, got %s", imported.TimeSignature)
+	}
+	if imported.Tuning != tab.Tuning {
+		t.Errorf("Expected tuning %v, got %v", tab.Tuning, imported.Tuning)
+	}
+	if imported.Capo != 2 {
+		t.Errorf("Expected capo 2, got %d", imported.Capo)
+	}
+	if imported.Content != tab.Content {
+		t.Errorf("Content mismatch:\nexpected %q\ngot      %q", tab.Content, imported.Content)
+	}
+}
+
+func TestReadMusicXMLChordsAndBackup(t *testing.T) {
+	doc := `<?xml version="1.0"?>
+<score-partwise version="4.0">
+  <part-list><score-part id="P1"><part-name>Guitar</part-name></score-part></part-list>
+  <part id="P1">
+    <measure number="1">
+      <attributes><divisions>2</divisions><staves>2</staves><clef number="2"><sign>TAB</sign></clef></attributes>
+      <note><pitch><step>E</step><octave>4</octave></pitch><duration>2</duration><staff>1</staff></note>
+      <backup><duration>2</duration></backup>
+      <note><pitch><step>E</step><octave>4</octave></pitch><duration>2</duration><staff>2</staff>
+        <notations><technical><string>1</string><fret>0</fret></technical></notations></note>
+      <note><chord/><pitch><step>B</step><octave>3</octave></pitch><duration>2</duration><staff>2</staff>
+        <notations><technical><string>2</string><fret>0</fret></technical></notations></note>
+      <note><pitch><step>C</step><octave>5</octave></pitch><duration>2</duration><staff>2</staff>
+        <notations><technical><string>2</string><fret>13</fret></technical></notations></note>
+    </measure>
+  </part>
+</score-partwise>`
+
+	tab, err := ReadMusicXML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ReadMusicXML failed: %v", err)
+	}
+
+	if tab.Measures != 1 {
+		t.Errorf("Expected 1 measure, got %d", tab.Measures)
+	}
+	if !strings.HasPrefix(tab.Content[0], "0---") {
+		t.Errorf("Unexpected high E string: %q", tab.Content[0])
+	}
+	if !strings.HasPrefix(tab.Content[1], "0---13--") {
+		t.Errorf("Unexpected B string: %q", tab.Content[1])
+	}
+}
+
+func TestMusicXMLFormRoundTrip(t *testing.T) {
+	tab := models.NewTestTab("Form")
+	tab.SetForm(models.MeasureForm{Measure: 0, RepeatStart: true, Segno: true})
+	tab.SetForm(models.MeasureForm{Measure: 1, Ending: 1, RepeatEnd: 3})
+	tab.SetForm(models.MeasureForm{Measure: 2, Ending: 2, ToCoda: true})
+	tab.SetForm(models.MeasureForm{Measure: 3, Coda: true, Jump: models.JumpDSalCoda})
+
+	var buf bytes.Buffer
+	if err := WriteMusicXML(&buf, tab); err != nil {
+		t.Fatalf("WriteMusicXML failed: %v", err)
+	}
+
+	imported, err := ReadMusicXML(&buf)
+	if err != nil {
+		t.Fatalf("ReadMusicXML failed: %v", err)
+	}
+
+	for _, want := range tab.Form {
+		if got := imported.FormAt(want.Measure); got != want {
+			t.Errorf("Measure %d: expected %+v, got %+v", want.Measure+1, want, got)
+		}
+	}
+}
+
+func TestMusicXMLMeterFitsMeasures(t *testing.T) {
+	tests := []struct {
+		signature string
+		time      string
+		kept      bool
+	}{
+		{"4/4", "<beats>4</beats><beat-type>4</beat-type>", false},
+		{"2/2", "<beats>2</beats><beat-type>2</beat-type>", false},
+		{"3/4", "<beats>4</beats><beat-type>4</beat-type>", true},
+		{"6/8", "<beats>8</beats><beat-type>8</beat-type>", true},
+	}
+	for _, tt := range tests {
+		tab := models.NewTestTab("Meter")
+		tab.TimeSignature = tt.signature
+
+		var buf bytes.Buffer
+		if err := WriteMusicXML(&buf, tab); err != nil {
+			t.Fatalf("WriteMusicXML failed: %v", err)
+		}
+		// Each measure holds 16 columns of a sixteenth, so the time must
+		// add up to a whole note
+		doc := strings.Join(strings.Fields(buf.String()), "")
+		if !strings.Contains(doc, "<time>"+tt.time+"</time>") {
+			t.Errorf("%s: expected the time %s in the output", tt.signature, tt.time)
+		}
+		if kept := strings.Contains(doc, meterField); kept != tt.kept {
+			t.Errorf("%s: expected the signature kept aside %v", tt.signature, tt.kept)
+		}
+
+		imported, err := ReadMusicXML(&buf)
+		if err != nil {
+			t.Fatalf("ReadMusicXML failed: %v", err)
+		}
+		if imported.TimeSignature != tt.signature {
+			t.Errorf("Expected time signature %s back, got %s", tt.signature, imported.TimeSignature)
+		}
+	}
+}
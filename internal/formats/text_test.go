@@ -0,0 +1,138 @@
+package formats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+func TestWriteLilyPond(t *testing.T) {
+	tab := models.NewTestTab("Lesson 1")
+	tab.Tempo = 90
+
+	var buf bytes.Buffer
+	if err := WriteLilyPond(&buf, tab); err != nil {
+		t.Fatalf("WriteLilyPond failed: %v", err)
+	}
+	out := buf.String()
+
+	expected := []string{
+		`title = "Lesson 1"`,
+		`\tempo 4 = 90`,
+		`\time 4/4`,
+		`\stringTuning <e, a, d g b e'>`,
+		// First measure: e string open, B string 1st fret, and so on
+		`<e'\1>8 <c'\2>8 <fis'\1>8 <d'\2>8 <gis'\1>8 <e'\2>8 <a'\1>4 | % 1`,
+		`r1 | % 2`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteAlphaTex(t *testing.T) {
+	tab := models.NewEmptyTab("Chords")
+	tab.SetFret(0, 0, 0)
+	tab.SetFret(1, 0, 1)
+	tab.SetFret(2, 6, 10)
+
+	var buf bytes.Buffer
+	if err := WriteAlphaTex(&buf, tab); err != nil {
+		t.Fatalf("WriteAlphaTex failed: %v", err)
+	}
+	out := buf.String()
+
+	expected := []string{
+		`\title "Chords"`,
+		`\tuning e4 b3 g3 d3 a2 e2`,
+		`\ts 4 4`,
+		`(0.1 1.2).4{d} 10.3.2 r.8 |`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteAlphaTexMeter(t *testing.T) {
+	tab := models.NewEmptyTab("Waltz")
+	tab.TimeSignature = "3/4"
+	tab.SetFret(0, 0, 3)
+
+	var buf bytes.Buffer
+	if err := WriteAlphaTex(&buf, tab); err != nil {
+		t.Fatalf("WriteAlphaTex failed: %v", err)
+	}
+	out := buf.String()
+
+	// A bar of 3/4 would be overfull with the 16 columns of a measure
+	if strings.Contains(out, `\ts 3 4`) || !strings.Contains(out, `\ts 4 4`) {
+		t.Errorf("Expected the time to fit a whole-note measure\n%s", out)
+	}
+	if !strings.Contains(out, "// 3/4 written as 4/4") {
+		t.Errorf("Expected the tab's own signature noted\n%s", out)
+	}
+}
+
+func TestWriteChordPro(t *testing.T) {
+	tab := models.NewTestTab("Song")
+	tab.Artist = "Singer"
+	tab.SetChord(0, "C")
+	tab.SetLyric(0, "Hel-")
+	tab.SetChord(4, "G")
+	tab.SetLyric(4, "lo")
+	tab.SetLyric(8, "world")
+	tab.SetChord(12, "Am")
+
+	var buf bytes.Buffer
+	if err := WriteChordPro(&buf, tab); err != nil {
+		t.Fatalf("WriteChordPro failed: %v", err)
+	}
+	out := buf.String()
+
+	expected := []string{
+		"{title: Song}",
+		"{artist: Singer}",
+		"[C]Hel[G]lo world [Am]\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteASCII(t *testing.T) {
+	tab := models.NewEmptyTab("Riff")
+	tab.Capo = 2
+	tab.MarkSection(0, "Intro")
+	tab.SetColumn(0, [6]int{0, 1, 0, 2, 3, -1}) // C shape, sounding D with the capo
+	tab.SetColumn(8, [6]int{0, 0, 0, 2, 2, 0})  // Em shape
+	tab.SetChord(0, "C")
+	tab.SetLyric(8, "la")
+
+	var buf bytes.Buffer
+	if err := WriteASCII(&buf, tab); err != nil {
+		t.Fatalf("WriteASCII failed: %v", err)
+	}
+	out := buf.String()
+
+	expected := []string{
+		"Riff\n",
+		"Tuning: E A D G B e | Capo 2 | Tempo 120",
+		"\n  [A] Intro\n  C\n  D       F#m\n",
+		"e|0-------0-------|----------------|",
+		"E|--------0-------|----------------|",
+		"\n          la\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q\n%s", want, out)
+		}
+	}
+}
@@ -2,7 +2,6 @@
 package midi
 
 import (
-	"strconv"
 	"sync"
 	"time"
 
@@ -27,7 +26,8 @@ type PlayableNote struct {
 	Duration time.Duration
 	Velocity int
 	String   int
-	Position int
+	Position int // Tab column, used for highlighting
+	Step     int // Column of the unrolled playback timeline
 }
 
 func NewPlayer() *Player {
@@ -101,15 +101,11 @@ func (p *Player) GetPosition() int {
 func (p *Player) convertTabToNotes(tab *models.Tab) []PlayableNote {
 	var notes []PlayableNote
 
-	// Standard guitar tuning MIDI notes (low to high)
-	// E(6th) A(5th) D(4th) G(3rd) B(2nd) e(1st) - but our array is reversed
-	stringMidiNotes := [6]int{64, 59, 55, 50, 45, 40} // e B G D A E (high to low as displayed)
-
-	maxLength := 0
-	for _, line := range tab.Content {
-		if len(line) > maxLength {
-			maxLength = len(line)
-		}
+	// Repeats and jumps are unrolled so the same column can sound several times
+	columns := tab.PlaybackColumns()
+	notesByColumn := make(map[int][]models.Note)
+	for _, note := range tab.Notes() {
+		notesByColumn[note.Position] = append(notesByColumn[note.Position], note)
 	}
 
 	// Use the tab's tempo if available, otherwise default
@@ -121,23 +117,21 @@ func (p *Player) convertTabToNotes(tab *models.Tab) []PlayableNote {
 	// Calculate note duration based on tempo (assume 16th notes)
 	beatDuration := time.Minute / time.Duration(tempo*4)
 
-	for pos := 0; pos < maxLength; pos++ {
-		for stringIdx, line := range tab.Content {
-			if pos < len(line) && line[pos] != '-' && line[pos] != '|' && line[pos] != ' ' {
-				if fret, err := strconv.Atoi(string(line[pos])); err == nil && fret >= 0 && fret <= 24 {
-					midiNote := stringMidiNotes[stringIdx] + fret
-
-					note := PlayableNote{
-						MidiNote: midiNote,
-						Start:    time.Duration(pos) * beatDuration,
-						Duration: beatDuration * 3 / 4, // Note length (slightly shorter than beat)
-						Velocity: 127,
-						String:   stringIdx,
-						Position: pos,
-					}
-					notes = append(notes, note)
-				}
+	for step, pos := range columns {
+		for _, tabNote := range notesByColumn[pos] {
+			// Pitch follows the tab's tuning and capo
+			midiNote := tab.MIDINote(tabNote.String, tabNote.Fret)
+
+			note := PlayableNote{
+				MidiNote: midiNote,
+				Start:    time.Duration(step) * beatDuration,
+				Duration: beatDuration * 3 / 4, // Note length (slightly shorter than beat)
+				Velocity: 127,
+				String:   tabNote.String,
+				Position: pos,
+				Step:     step,
 			}
+			notes = append(notes, note)
 		}
 	}
 
@@ -166,18 +160,14 @@ func (p *Player) playbackLoop() {
 
 	maxPos := 0
 	for _, note := range p.notes {
-		if note.Position > maxPos {
-			maxPos = note.Position
+		if note.Step > maxPos {
+			maxPos = note.Step
 		}
 	}
 
-	// If no notes, determine max position from tab content
+	// If no notes, determine max position from the playback timeline
 	if maxPos == 0 && p.currentTab != nil {
-		for _, line := range p.currentTab.Content {
-			if len(line) > maxPos {
-				maxPos = len(line)
-			}
-		}
+		maxPos = len(p.currentTab.PlaybackColumns())
 	}
 
 	startTime := time.Now()
@@ -195,7 +185,7 @@ func (p *Player) playbackLoop() {
 			// Update highlighted positions based on current position
 			p.highlighted = nil
 			for _, note := range p.notes {
-				if note.Position == p.position {
+				if note.Step == p.position {
 					p.highlighted = append(p.highlighted, models.Position{
 						String:   note.String,
 						Position: note.Position,
@@ -207,7 +197,7 @@ func (p *Player) playbackLoop() {
 			// In a real implementation, this would trigger actual MIDI output
 			notesAtPosition := 0
 			for _, note := range p.notes {
-				if note.Position == p.position {
+				if note.Step == p.position {
 					notesAtPosition++
 					// Here you would send MIDI note on/off commands
 					// fmt.Printf("Playing MIDI note %d on string %d at position %d\n",
@@ -234,11 +224,7 @@ func (p *Player) GetPlaybackInfo() (position int, totalLength int, isPlaying boo
 
 	maxPos := 0
 	if p.currentTab != nil {
-		for _, line := range p.currentTab.Content {
-			if len(line) > maxPos {
-				maxPos = len(line)
-			}
-		}
+		maxPos = len(p.currentTab.PlaybackColumns())
 	}
 
 	return p.position, maxPos, p.isPlaying
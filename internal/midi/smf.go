@@ -0,0 +1,153 @@
+// internal/midi/smf.go
+package midi
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// ticksPerQuarter is the resolution of written files; each tab column is
+// a sixteenth note
+const (
+	ticksPerQuarter = 480
+	ticksPerColumn  = ticksPerQuarter / 4
+)
+
+// General MIDI program and velocity used for the exported track
+const (
+	guitarProgram = 25 // Acoustic Guitar (steel), counting from 0
+	fileVelocity  = 100
+)
+
+// smfEvent is a channel event at an absolute time in ticks
+type smfEvent struct {
+	tick  int
+	order int // Note-offs sort before note-ons at the same tick
+	data  []byte
+}
+
+// WriteSMF writes the tab as a format 0 Standard MIDI File. Repeats and
+// jumps are written out in playback order, and each note rings until the
+// next note on its string or for at most one measure.
+func WriteSMF(w io.Writer, tab *models.Tab) error {
+	tempo := tab.Tempo
+	if tempo <= 0 {
+		tempo = 120
+	}
+	beats, beatType := 4, 4
+	if _, err := fmt.Sscanf(tab.TimeSignature, "%d/%d", &beats, &beatType); err != nil || beats <= 0 || beatType <= 0 {
+		beats, beatType = 4, 4
+	}
+
+	var track []byte
+	event := func(delta int, data ...byte) {
+		track = appendVarLen(track, delta)
+		track = append(track, data...)
+	}
+	meta := func(kind byte, data []byte) {
+		event(0, 0xFF, kind)
+		track = appendVarLen(track, len(data))
+		track = append(track, data...)
+	}
+
+	meta(0x03, []byte(tab.Name))
+	microseconds := 60000000 / tempo
+	meta(0x51, []byte{byte(microseconds >> 16), byte(microseconds >> 8), byte(microseconds)})
+	meta(0x58, []byte{byte(beats), byte(log2(beatType)), 24, 8})
+	event(0, 0xC0, guitarProgram)
+
+	events := noteEvents(tab)
+	last := 0
+	for _, e := range events {
+		event(e.tick-last, e.data...)
+		last = e.tick
+	}
+	meta(0x2F, nil)
+
+	out := bufio.NewWriter(w)
+	header := []byte("MThd")
+	header = binary.BigEndian.AppendUint32(header, 6)
+	header = binary.BigEndian.AppendUint16(header, 0) // Format 0: a single track
+	header = binary.BigEndian.AppendUint16(header, 1)
+	header = binary.BigEndian.AppendUint16(header, ticksPerQuarter)
+	header = append(header, "MTrk"...)
+	header = binary.BigEndian.AppendUint32(header, uint32(len(track)))
+	if _, err := out.Write(header); err != nil {
+		return err
+	}
+	if _, err := out.Write(track); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// noteEvents builds the note-on and note-off events of the tab in time order
+func noteEvents(tab *models.Tab) []smfEvent {
+	columns := tab.PlaybackColumns()
+	notesByColumn := make(map[int][]models.Note)
+	for _, note := range tab.Notes() {
+		notesByColumn[note.Position] = append(notesByColumn[note.Position], note)
+	}
+
+	// Steps of the timeline at which each string is played
+	var starts [6][]int
+	for step, pos := range columns {
+		for _, note := range notesByColumn[pos] {
+			starts[note.String] = append(starts[note.String], step)
+		}
+	}
+
+	var events []smfEvent
+	for step, pos := range columns {
+		for _, note := range notesByColumn[pos] {
+			end := min(step+models.MeasureLength, len(columns))
+			for _, next := range starts[note.String] {
+				if next > step {
+					end = min(end, next)
+					break
+				}
+			}
+
+			pitch := byte(max(0, min(127, tab.MIDINote(note.String, note.Fret))))
+			events = append(events,
+				smfEvent{tick: step * ticksPerColumn, order: 1, data: []byte{0x90, pitch, fileVelocity}},
+				smfEvent{tick: end * ticksPerColumn, order: 0, data: []byte{0x80, pitch, 0}})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].tick != events[j].tick {
+			return events[i].tick < events[j].tick
+		}
+		return events[i].order < events[j].order
+	})
+	return events
+}
+
+// appendVarLen appends a MIDI variable-length quantity
+func appendVarLen(b []byte, value int) []byte {
+	var groups []byte
+	groups = append(groups, byte(value&0x7F))
+	for value >>= 7; value > 0; value >>= 7 {
+		groups = append(groups, byte(value&0x7F)|0x80)
+	}
+	for i := len(groups) - 1; i >= 0; i-- {
+		b = append(b, groups[i])
+	}
+	return b
+}
+
+// log2 returns the power of two of a time signature denominator
+func log2(n int) int {
+	power := 0
+	for n > 1 {
+		n >>= 1
+		power++
+	}
+	return power
+}
@@ -0,0 +1,74 @@
+package midi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+func TestWriteSMF(t *testing.T) {
+	tab := models.NewEmptyTab("Riff")
+	tab.Tempo = 100
+	tab.SetFret(5, 0, 0) // Low E
+	tab.SetFret(5, 4, 3) // G on the same string cuts the E off
+	tab.SetFret(0, 4, 0) // High e rings to the end of its measure
+
+	var buf bytes.Buffer
+	if err := WriteSMF(&buf, tab); err != nil {
+		t.Fatalf("WriteSMF failed: %v", err)
+	}
+	data := buf.Bytes()
+
+	if string(data[:4]) != "MThd" || string(data[14:18]) != "MTrk" {
+		t.Fatalf("Expected header and track chunks, got % x", data[:18])
+	}
+	if division := binary.BigEndian.Uint16(data[12:14]); division != ticksPerQuarter {
+		t.Errorf("Expected %d ticks per quarter, got %d", ticksPerQuarter, division)
+	}
+	track := data[22:]
+	if length := binary.BigEndian.Uint32(data[18:22]); int(length) != len(track) {
+		t.Errorf("Track length %d does not match %d bytes of data", length, len(track))
+	}
+	if !bytes.HasSuffix(track, []byte{0x00, 0xFF, 0x2F, 0x00}) {
+		t.Error("Expected the track to end with an end-of-track event")
+	}
+	// 60,000,000 / 100 BPM = 600,000 microseconds per quarter
+	if !bytes.Contains(track, []byte{0xFF, 0x51, 0x03, 0x09, 0x27, 0xC0}) {
+		t.Error("Expected a tempo event for 100 BPM")
+	}
+
+	events := noteEvents(tab)
+	expected := []smfEvent{
+		{tick: 0, data: []byte{0x90, 40, fileVelocity}},
+		{tick: 4 * ticksPerColumn, data: []byte{0x80, 40, 0}},
+		{tick: 4 * ticksPerColumn, data: []byte{0x90, 64, fileVelocity}},
+		{tick: 4 * ticksPerColumn, data: []byte{0x90, 43, fileVelocity}},
+		{tick: 20 * ticksPerColumn, data: []byte{0x80, 64, 0}},
+		{tick: 20 * ticksPerColumn, data: []byte{0x80, 43, 0}},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(events))
+	}
+	for i, want := range expected {
+		if events[i].tick != want.tick || !bytes.Equal(events[i].data, want.data) {
+			t.Errorf("Event %d: expected %d % x, got %d % x", i, want.tick, want.data, events[i].tick, events[i].data)
+		}
+	}
+}
+
+func TestAppendVarLen(t *testing.T) {
+	cases := map[int][]byte{
+		0:       {0x00},
+		0x7F:    {0x7F},
+		0x80:    {0x81, 0x00},
+		0x3FFF:  {0xFF, 0x7F},
+		0x10000: {0x84, 0x80, 0x00},
+	}
+	for value, want := range cases {
+		if got := appendVarLen(nil, value); !bytes.Equal(got, want) {
+			t.Errorf("appendVarLen(%#x) = % x, want % x", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// internal/models/capo.go
+package models
+
+import "time"
+
+// RewriteForCapo moves the capo while keeping every note at the same pitch,
+// rewriting frets relative to the new capo. Moving the capo from 0 turns
+// absolute frets into capo-relative ones; moving it back to 0 undoes that.
+// Notes that would land behind the capo, past the last fret or on a
+// neighbouring note keep their fret; their number is returned.
+func (t *Tab) RewriteForCapo(capo int) int {
+	if capo < 0 || capo > MaxFret {
+		return 0
+	}
+
+	shift := t.Capo - capo
+	failed := 0
+	for _, note := range t.Notes() {
+		t.clearNote(note)
+		fret := note.Fret + shift
+		if !t.fretFits(note.String, note.Position, fret) {
+			// Behind the capo, past the last fret or no room for two digits
+			t.SetFret(note.String, note.Position, note.Fret)
+			failed++
+			continue
+		}
+		t.SetFret(note.String, note.Position, fret)
+	}
+
+	t.Capo = capo
+	t.UpdatedAt = time.Now()
+	return failed
+}
@@ -0,0 +1,25 @@
+package models
+
+import "testing"
+
+func TestRewriteForCapo(t *testing.T) {
+	tab := NewEmptyTab("Capo")
+	tab.SetFret(0, 0, 5)
+	tab.SetFret(1, 4, 1)
+	pitch := tab.MIDINote(0, 5)
+
+	if failed := tab.RewriteForCapo(2); failed != 1 {
+		t.Errorf("Expected the note behind the capo to fail, got %d failures", failed)
+	}
+	if note, ok := tab.NoteAt(0, 0); !ok || note.Fret != 3 || tab.MIDINote(0, note.Fret) != pitch {
+		t.Errorf("Expected fret 3 sounding the same pitch, got %q", tab.Content[0])
+	}
+	if note, ok := tab.NoteAt(1, 4); !ok || note.Fret != 1 {
+		t.Errorf("Expected the note behind the capo to stay, got %q", tab.Content[1])
+	}
+
+	tab.RewriteForCapo(0)
+	if note, ok := tab.NoteAt(0, 0); !ok || note.Fret != 5 || tab.Capo != 0 {
+		t.Errorf("Expected absolute fret 5 without capo, got %q capo %d", tab.Content[0], tab.Capo)
+	}
+}
@@ -0,0 +1,91 @@
+// internal/models/clip.go
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Clip is a block of tab content copied from a selection, one line per
+// string from the highest selected string down
+type Clip struct {
+	Lines    []string
+	Linewise bool // Whole strings, pasted over whole strings
+}
+
+// Empty reports whether the clip holds nothing to paste
+func (c Clip) Empty() bool {
+	return len(c.Lines) == 0
+}
+
+// Width returns the number of columns in the clip
+func (c Clip) Width() int {
+	width := 0
+	for _, line := range c.Lines {
+		width = max(width, len(line))
+	}
+	return width
+}
+
+// clampSelection keeps the selection within the strings and columns of
+// the tab, reporting false if nothing is left
+func (t *Tab) clampSelection(sel Selection) (Selection, bool) {
+	sel.FirstString = max(sel.FirstString, 0)
+	sel.LastString = min(sel.LastString, 5)
+	sel.Start = max(sel.Start, 0)
+	sel.End = min(sel.End, t.GetTotalLength()-1)
+	return sel, sel.FirstString <= sel.LastString && sel.Start <= sel.End
+}
+
+// Yank copies the content of the selection
+func (t *Tab) Yank(sel Selection) Clip {
+	sel, ok := t.clampSelection(sel)
+	if !ok {
+		return Clip{}
+	}
+	var clip Clip
+	for str := sel.FirstString; str <= sel.LastString; str++ {
+		clip.Lines = append(clip.Lines, t.Content[str][sel.Start:sel.End+1])
+	}
+	return clip
+}
+
+// ClearBlock replaces the selection with rests. A two-digit fret cut by
+// the edge of the selection is cleared whole, so no stray digit is left.
+func (t *Tab) ClearBlock(sel Selection) {
+	sel, ok := t.clampSelection(sel)
+	if !ok {
+		return
+	}
+	for str := sel.FirstString; str <= sel.LastString; str++ {
+		start, end := sel.Start, sel.End
+		if note, ok := t.NoteAt(str, start); ok {
+			start = note.Position
+		}
+		if note, ok := t.NoteAt(str, end); ok {
+			end = note.Position + note.Width - 1
+		}
+		line := t.Content[str]
+		t.Content[str] = line[:start] + strings.Repeat("-", end-start+1) + line[end+1:]
+	}
+	t.UpdatedAt = time.Now()
+}
+
+// PasteClip writes the clip over the tab with its top line on the given
+// string and its first column at pos. Lines and columns past the edges of
+// the tab are dropped.
+func (t *Tab) PasteClip(clip Clip, str, pos int) {
+	length := t.GetTotalLength()
+	if pos < 0 || pos >= length {
+		return
+	}
+	for i, text := range clip.Lines {
+		if str+i < 0 || str+i > 5 {
+			continue
+		}
+		line := []byte(t.Content[str+i])
+		copy(line[pos:], text)
+		t.Content[str+i] = string(line)
+	}
+	t.UpdatedAt = time.Now()
+}
@@ -0,0 +1,42 @@
+package models
+
+import "testing"
+
+func TestYankClearAndPaste(t *testing.T) {
+	tab := newFormTab(2)
+	tab.Content[0] = "0-12-5----------" + "----------------"
+	tab.Content[1] = "--3---7---------" + "----------------"
+
+	clip := tab.Yank(Selection{FirstString: 0, LastString: 1, Start: 0, End: 5})
+	if len(clip.Lines) != 2 || clip.Lines[0] != "0-12-5" || clip.Lines[1] != "--3---" {
+		t.Errorf("Unexpected yank: %q", clip.Lines)
+	}
+	if clip.Width() != 6 {
+		t.Errorf("Expected a width of 6, got %d", clip.Width())
+	}
+
+	// Clearing from the second digit of 12 takes the whole fret
+	tab.ClearBlock(Selection{FirstString: 0, LastString: 0, Start: 3, End: 4})
+	if expected := "0----5----------" + "----------------"; tab.Content[0] != expected {
+		t.Errorf("Clear:\nexpected %q\ngot      %q", expected, tab.Content[0])
+	}
+
+	// Pasting past the last column and string drops what does not fit
+	tab.PasteClip(clip, 5, 28)
+	if expected := "----------------" + "------------0-12"; tab.Content[5] != expected {
+		t.Errorf("Paste:\nexpected %q\ngot      %q", expected, tab.Content[5])
+	}
+	tab.PasteClip(clip, 0, 16)
+	if expected := "0----5----------" + "0-12-5----------"; tab.Content[0] != expected {
+		t.Errorf("Paste:\nexpected %q\ngot      %q", expected, tab.Content[0])
+	}
+	for str, line := range tab.Content {
+		if len(line) != 2*MeasureLength {
+			t.Errorf("String %d has length %d", str, len(line))
+		}
+	}
+
+	if clip := tab.Yank(Selection{FirstString: 0, LastString: 0, Start: 40, End: 50}); !clip.Empty() {
+		t.Errorf("Expected nothing from outside the tab, got %q", clip.Lines)
+	}
+}
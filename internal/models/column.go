@@ -0,0 +1,155 @@
+// internal/models/column.go
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// ShiftScope selects how far inserting or deleting a column shifts the
+// columns after it
+type ShiftScope int
+
+const (
+	ShiftMeasure ShiftScope = iota // Up to the bar line; a note pushed past it is dropped whole
+	ShiftTab                       // To the end of the tab, which grows by a measure if needed
+)
+
+func (s ShiftScope) String() string {
+	if s == ShiftTab {
+		return "tab"
+	}
+	return "measure"
+}
+
+// shiftEnd returns the column where shifting stops for the given scope
+func (t *Tab) shiftEnd(pos int, scope ShiftScope) int {
+	if scope == ShiftTab {
+		return t.GetTotalLength()
+	}
+	return (pos/MeasureLength + 1) * MeasureLength
+}
+
+// columnEmpty reports whether no string has a note in the column
+func (t *Tab) columnEmpty(pos int) bool {
+	for _, line := range t.Content {
+		if pos < len(line) && line[pos] != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// InsertColumn inserts an empty column across all strings at pos, shifting
+// the following columns, chords and lyrics one step right. It returns
+// false, leaving the tab unchanged, when a fret would be split (see
+// shiftColumns).
+func (t *Tab) InsertColumn(pos int, scope ShiftScope) bool {
+	if pos < 0 || pos >= t.GetTotalLength() {
+		return false
+	}
+	grown := scope == ShiftTab && !t.columnEmpty(t.GetTotalLength()-1)
+	if grown {
+		t.AddMeasure()
+	}
+
+	end := t.shiftEnd(pos, scope)
+	if !t.shiftColumns(pos, end, 1) {
+		if grown {
+			t.RemoveMeasure()
+		}
+		return false
+	}
+	t.Chords = shiftAnnotations(t.Chords, pos, end, 1)
+	t.Lyrics = shiftAnnotations(t.Lyrics, pos, end, 1)
+	t.Marks = shiftMarks(t.Marks, pos, end, 1)
+	t.UpdatedAt = time.Now()
+	return true
+}
+
+// DeleteColumn removes the column at pos across all strings, shifting the
+// following columns, chords and lyrics one step left. It returns false,
+// leaving the tab unchanged, when a fret would be split (see shiftColumns).
+func (t *Tab) DeleteColumn(pos int, scope ShiftScope) bool {
+	if pos < 0 || pos >= t.GetTotalLength() {
+		return false
+	}
+
+	end := t.shiftEnd(pos, scope)
+	if !t.shiftColumns(pos, end, -1) {
+		return false
+	}
+	t.Chords = shiftAnnotations(t.Chords, pos, end, -1)
+	t.Lyrics = shiftAnnotations(t.Lyrics, pos, end, -1)
+	t.Marks = shiftMarks(t.Marks, pos, end, -1)
+	t.UpdatedAt = time.Now()
+	return true
+}
+
+// shiftColumns moves the cells of every string in [pos, end) one column
+// right (delta 1) or left (delta -1). A note on the cell that falls out,
+// the deleted one or the one pushed to end, is dropped whole, both digits
+// of a two-digit fret included. Nothing changes and false is returned when
+// the shift would change a fret that moves: cutting it at end, inserting
+// between its digits, joining it to a neighbour (1-2 becoming 12) or
+// moving it across a bar line.
+func (t *Tab) shiftColumns(pos, end, delta int) bool {
+	dropped := pos
+	if delta > 0 {
+		dropped = end - 1
+	}
+
+	content := t.Content
+	for str, line := range t.Content {
+		if end > len(line) {
+			continue
+		}
+		cells := []byte(line)
+		var want []Note
+		for _, note := range lineNotes(str, line) {
+			if dropped >= note.Position && dropped < note.Position+note.Width {
+				copy(cells[note.Position:note.Position+note.Width], "--")
+				continue
+			}
+			if note.Position >= pos && note.Position < end {
+				note.Position += delta
+				if note.Width == 2 && (note.Position+1)%MeasureLength == 0 {
+					return false // Across a bar line
+				}
+			}
+			want = append(want, note)
+		}
+
+		line = string(cells)
+		if delta > 0 {
+			line = line[:pos] + "-" + line[pos:end-1] + line[end:]
+		} else {
+			line = line[:pos] + line[pos+1:end] + "-" + line[end:]
+		}
+		if !slices.Equal(lineNotes(str, line), want) {
+			return false
+		}
+		content[str] = line
+	}
+	t.Content = content
+	return true
+}
+
+// shiftAnnotations moves annotations anchored in [pos, end) by delta
+// columns. Annotations on a deleted column or pushed to end are dropped.
+func shiftAnnotations(lane []Annotation, pos, end, delta int) []Annotation {
+	var result []Annotation
+	for _, a := range lane {
+		if a.Position >= pos && a.Position < end {
+			if delta < 0 && a.Position == pos {
+				continue
+			}
+			a.Position += delta
+			if a.Position >= end {
+				continue
+			}
+		}
+		result = append(result, a)
+	}
+	return result
+}
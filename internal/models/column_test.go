@@ -0,0 +1,139 @@
+package models
+
+import "testing"
+
+func TestInsertAndDeleteColumn(t *testing.T) {
+	tab := newFormTab(2)
+	tab.Content[0] = "0-2-------------" + "12--------------"
+	tab.SetChord(2, "G")
+
+	tab.InsertColumn(1, ShiftMeasure)
+	if expected := "0--2------------" + "12--------------"; tab.Content[0] != expected {
+		t.Errorf("Insert within measure:\nexpected %q\ngot      %q", expected, tab.Content[0])
+	}
+	if tab.ChordAt(3) != "G" {
+		t.Errorf("Expected the chord to move with its note, got %v", tab.Chords)
+	}
+
+	tab.DeleteColumn(1, ShiftMeasure)
+	if expected := "0-2-------------" + "12--------------"; tab.Content[0] != expected {
+		t.Errorf("Delete within measure:\nexpected %q\ngot      %q", expected, tab.Content[0])
+	}
+
+	// Through the tab the 12 would straddle the bar line, so nothing moves
+	if tab.DeleteColumn(1, ShiftTab) {
+		t.Error("Expected a delete moving 12 across the bar line to be refused")
+	}
+	if expected := "0-2-------------" + "12--------------"; tab.Content[0] != expected {
+		t.Errorf("Refused delete:\nexpected %q\ngot      %q", expected, tab.Content[0])
+	}
+	if tab.ChordAt(2) != "G" {
+		t.Errorf("Expected the chord left in place, got %v", tab.Chords)
+	}
+
+	// A note in the last column makes room for itself rather than falling off
+	tab.Content[1] = tab.Content[1][:31] + "5"
+	tab.InsertColumn(0, ShiftTab)
+	if tab.GetMeasureCount() != 3 || tab.Measures != 3 {
+		t.Errorf("Expected the tab to grow to 3 measures, got %d", tab.GetMeasureCount())
+	}
+	for str, line := range tab.Content {
+		if len(line) != 3*MeasureLength {
+			t.Errorf("String %d has length %d", str, len(line))
+		}
+	}
+	if note, ok := tab.NoteAt(1, 32); !ok || note.Fret != 5 {
+		t.Errorf("Expected the last note to move into the new measure")
+	}
+}
+
+func TestShiftTwoDigitFrets(t *testing.T) {
+	tab := newFormTab(2)
+
+	// A two-digit fret pushed over the bar line is dropped whole, not
+	// cut down to its first digit
+	tab.Content[0] = "--------------12" + "----------------"
+	if !tab.InsertColumn(0, ShiftMeasure) {
+		t.Fatal("Expected the insert to succeed")
+	}
+	if expected := "----------------" + "----------------"; tab.Content[0] != expected {
+		t.Errorf("Insert pushing 12 out:\nexpected %q\ngot      %q", expected, tab.Content[0])
+	}
+
+	// Deleting either digit's column removes the whole fret
+	for _, pos := range []int{3, 4} {
+		tab.Content[0] = "---12-5---------" + "----------------"
+		if !tab.DeleteColumn(pos, ShiftMeasure) {
+			t.Fatalf("Expected the delete at %d to succeed", pos)
+		}
+		if expected := "-----5----------" + "----------------"; tab.Content[0] != expected {
+			t.Errorf("Delete at %d:\nexpected %q\ngot      %q", pos, expected, tab.Content[0])
+		}
+	}
+
+	refused := []struct {
+		name    string
+		content string
+		insert  bool
+		pos     int
+	}{
+		{"insert between the digits", "---12-----------", true, 4},
+		{"delete joining 1 and 2 into 12", "-1-2------------", false, 2},
+	}
+	for _, tc := range refused {
+		tab.Content[0] = tc.content + "----------------"
+		before := tab.Content
+		var ok bool
+		if tc.insert {
+			ok = tab.InsertColumn(tc.pos, ShiftMeasure)
+		} else {
+			ok = tab.DeleteColumn(tc.pos, ShiftMeasure)
+		}
+		if ok || tab.Content != before {
+			t.Errorf("Expected %s to be refused, got %q", tc.name, tab.Content[0])
+		}
+	}
+}
+
+func TestSetColumn(t *testing.T) {
+	tab := NewEmptyTab("Column")
+	tab.Content[0] = "-5-3" + tab.Content[0][4:]
+	tab.Content[5] = "7---" + tab.Content[5][4:]
+
+	failed := tab.SetColumn(1, [6]int{0, 1, 0, 2, 3, -1})
+	if failed != 0 {
+		t.Errorf("Expected every fret to fit, %d failed", failed)
+	}
+	if tab.Content[0][:4] != "-0-3" {
+		t.Errorf("Expected the old note to be replaced, got %q", tab.Content[0][:4])
+	}
+	if note, ok := tab.NoteAt(4, 1); !ok || note.Fret != 3 {
+		t.Errorf("Expected fret 3 on the A string, got %q", tab.Content[4][:4])
+	}
+
+	// Writing next to the 7 would turn it into 71, which is not a fret
+	if failed := tab.SetColumn(1, [6]int{-1, -1, -1, -1, -1, 5}); failed != 1 {
+		t.Errorf("Expected the low E fret not to fit, %d failed", failed)
+	}
+	if tab.Content[5][:4] != "7---" {
+		t.Errorf("Expected the low E string to be unchanged, got %q", tab.Content[5][:4])
+	}
+}
+
+func TestPlaceFret(t *testing.T) {
+	tab := NewEmptyTab("Place")
+	tab.Content[2] = "57-" + tab.Content[2][3:] // Two notes, as 57 is past the last fret
+
+	if !tab.PlaceFret(2, 0, 12) || tab.Content[2][:3] != "12-" {
+		t.Errorf("Expected 12 to replace both notes, got %q", tab.Content[2][:3])
+	}
+	if tab.PlaceFret(2, 2, 3) {
+		t.Error("Expected a fret next to another fret not to fit")
+	}
+	if tab.Content[2][:3] != "12-" {
+		t.Errorf("Expected the string to be unchanged, got %q", tab.Content[2][:3])
+	}
+	if !tab.RemoveNote(2, 1) || tab.Content[2][:3] != "---" {
+		t.Errorf("Expected the note to be removed, got %q", tab.Content[2][:3])
+	}
+}
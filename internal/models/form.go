@@ -0,0 +1,266 @@
+// internal/models/form.go
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Jump is a navigation instruction written at the end of a measure
+type Jump string
+
+const (
+	JumpNone     Jump = ""
+	JumpDC       Jump = "D.C."
+	JumpDCalFine Jump = "D.C. al Fine"
+	JumpDCalCoda Jump = "D.C. al Coda"
+	JumpDS       Jump = "D.S."
+	JumpDSalFine Jump = "D.S. al Fine"
+	JumpDSalCoda Jump = "D.S. al Coda"
+)
+
+// maxRepeatPass caps how often a single repeat is played
+const maxRepeatPass = 8
+
+// Jumps lists the jump instructions in the order the editor cycles through them
+var Jumps = []Jump{JumpNone, JumpDC, JumpDCalFine, JumpDCalCoda, JumpDS, JumpDSalFine, JumpDSalCoda}
+
+// MeasureForm holds the repeat and navigation markings of one measure
+type MeasureForm struct {
+	Measure     int  `json:"measure"`
+	RepeatStart bool `json:"repeat_start,omitempty"`
+	RepeatEnd   int  `json:"repeat_end,omitempty"` // Total plays of the repeated passage, 0 if no repeat
+	Ending      int  `json:"ending,omitempty"`     // Volta (1st/2nd ending) number, 0 if none
+	Segno       bool `json:"segno,omitempty"`
+	Coda        bool `json:"coda,omitempty"`    // Start of the coda
+	ToCoda      bool `json:"to_coda,omitempty"` // Leave for the coda here after an "al Coda" jump
+	Fine        bool `json:"fine,omitempty"`
+	Jump        Jump `json:"jump,omitempty"`
+}
+
+// IsEmpty reports whether the measure carries no markings
+func (f MeasureForm) IsEmpty() bool {
+	f.Measure = 0
+	return f == MeasureForm{}
+}
+
+// FormAt returns the markings of a measure
+func (t *Tab) FormAt(measure int) MeasureForm {
+	for _, f := range t.Form {
+		if f.Measure == measure {
+			return f
+		}
+	}
+	return MeasureForm{Measure: measure}
+}
+
+// SetForm replaces the markings of a measure, dropping empty entries
+func (t *Tab) SetForm(form MeasureForm) {
+	var result []MeasureForm
+	for _, f := range t.Form {
+		if f.Measure != form.Measure {
+			result = append(result, f)
+		}
+	}
+	if !form.IsEmpty() {
+		result = append(result, form)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Measure < result[j].Measure })
+	t.Form = result
+	t.UpdatedAt = time.Now()
+}
+
+// HasForm reports whether any measure carries repeat or navigation markings
+func (t *Tab) HasForm() bool {
+	return len(t.Form) > 0
+}
+
+// endingGroupLast returns the highest volta number in the run of ending
+// measures containing the measure
+func (t *Tab) endingGroupLast(measure int) int {
+	start := measure
+	for start > 0 && t.FormAt(start-1).Ending > 0 {
+		start--
+	}
+	last := 0
+	for m := start; m < t.GetMeasureCount() && t.FormAt(m).Ending > 0; m++ {
+		if e := t.FormAt(m).Ending; e > last {
+			last = e
+		}
+	}
+	return last
+}
+
+// PlaybackOrder unrolls repeats, voltas and D.C./D.S. jumps into the order
+// in which measures are played. Following common practice, repeats are
+// not taken again after a jump and only the last ending is played.
+func (t *Tab) PlaybackOrder() []int {
+	count := t.GetMeasureCount()
+	if !t.HasForm() {
+		order := make([]int, count)
+		for i := range order {
+			order[i] = i
+		}
+		return order
+	}
+
+	var order []int
+	repeatStart := 0 // Where a backward repeat returns to
+	pass := 1        // Current pass through the repeated passage
+	afterJump := false
+	alCoda := false
+	jumpsTaken := make(map[int]bool)
+	inEnding := false
+	limit := count * maxRepeatPass * 4 // Guard against malformed markings
+
+	for i := 0; i < count && len(order) < limit; {
+		f := t.FormAt(i)
+
+		if f.RepeatStart && i != repeatStart {
+			repeatStart = i
+			pass = 1
+		}
+
+		// Leaving a run of endings completes the repeated passage
+		if f.Ending == 0 && inEnding {
+			inEnding = false
+			repeatStart = i
+			pass = 1
+		}
+
+		if f.Ending > 0 {
+			inEnding = true
+			wanted := pass
+			if afterJump {
+				wanted = t.endingGroupLast(i)
+			}
+			if f.Ending != wanted {
+				i++
+				continue
+			}
+		}
+
+		order = append(order, i)
+
+		if afterJump && f.Fine && !alCoda {
+			break
+		}
+
+		if afterJump && alCoda && f.ToCoda {
+			coda := -1
+			for m := i + 1; m < count; m++ {
+				if t.FormAt(m).Coda {
+					coda = m
+					break
+				}
+			}
+			if coda != -1 {
+				alCoda = false
+				i = coda
+				continue
+			}
+		}
+
+		if f.RepeatEnd > 0 && !afterJump {
+			times := f.RepeatEnd
+			if times > maxRepeatPass {
+				times = maxRepeatPass
+			}
+			if pass < times {
+				pass++
+				inEnding = false
+				i = repeatStart
+				continue
+			}
+			if f.Ending == 0 {
+				repeatStart = i + 1
+				pass = 1
+			}
+		}
+
+		if f.Jump != JumpNone && !jumpsTaken[i] {
+			jumpsTaken[i] = true
+			afterJump = true
+			alCoda = strings.HasSuffix(string(f.Jump), "Coda")
+			target := 0
+			if strings.HasPrefix(string(f.Jump), "D.S.") {
+				for m := 0; m < count; m++ {
+					if t.FormAt(m).Segno {
+						target = m
+						break
+					}
+				}
+			}
+			repeatStart = -1
+			pass = 1
+			inEnding = false
+			i = target
+			continue
+		}
+
+		i++
+	}
+
+	return order
+}
+
+// PlaybackColumns maps each step of playback to the tab column it plays,
+// following PlaybackOrder
+func (t *Tab) PlaybackColumns() []int {
+	order := t.PlaybackOrder()
+	columns := make([]int, 0, len(order)*MeasureLength)
+	for _, measure := range order {
+		for c := 0; c < MeasureLength; c++ {
+			columns = append(columns, measure*MeasureLength+c)
+		}
+	}
+	return columns
+}
+
+// Unrolled returns a copy of the tab with repeats and jumps written out in
+// playback order and the form markings removed
+func (t *Tab) Unrolled() *Tab {
+	order := t.PlaybackOrder()
+	unrolled := *t
+	unrolled.Form = nil
+	unrolled.Sections = nil
+	unrolled.Chords = nil
+	unrolled.Lyrics = nil
+
+	for str := 0; str < 6; str++ {
+		var b strings.Builder
+		for _, measure := range order {
+			start := measure * MeasureLength
+			if start+MeasureLength <= len(t.Content[str]) {
+				b.WriteString(t.Content[str][start : start+MeasureLength])
+			}
+		}
+		unrolled.Content[str] = b.String()
+	}
+
+	for step, measure := range order {
+		offset := (step - measure) * MeasureLength
+		for _, a := range t.Chords {
+			if a.Position/MeasureLength == measure {
+				unrolled.Chords = append(unrolled.Chords, Annotation{Position: a.Position + offset, Text: a.Text})
+			}
+		}
+		for _, a := range t.Lyrics {
+			if a.Position/MeasureLength == measure {
+				unrolled.Lyrics = append(unrolled.Lyrics, Annotation{Position: a.Position + offset, Text: a.Text})
+			}
+		}
+		for _, s := range t.Sections {
+			if s.Start == measure {
+				unrolled.Sections = append(unrolled.Sections, Section{Name: s.Name, Start: step, End: step})
+			} else if n := len(unrolled.Sections); n > 0 && measure > s.Start && measure <= s.End &&
+				unrolled.Sections[n-1].Name == s.Name && unrolled.Sections[n-1].End == step-1 {
+				unrolled.Sections[n-1].End = step
+			}
+		}
+	}
+
+	unrolled.Measures = len(order)
+	return &unrolled
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newFormTab(measures int, form ...MeasureForm) *Tab {
+	tab := NewEmptyTab("Form")
+	for tab.Measures < measures {
+		tab.AddMeasure()
+	}
+	for tab.Measures > measures {
+		tab.RemoveMeasure()
+	}
+	for _, f := range form {
+		tab.SetForm(f)
+	}
+	return tab
+}
+
+func TestPlaybackOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		tab      *Tab
+		expected []int
+	}{
+		{
+			name:     "no markings",
+			tab:      newFormTab(3),
+			expected: []int{0, 1, 2},
+		},
+		{
+			name: "simple repeat",
+			tab: newFormTab(4,
+				MeasureForm{Measure: 1, RepeatStart: true},
+				MeasureForm{Measure: 2, RepeatEnd: 2}),
+			expected: []int{0, 1, 2, 1, 2, 3},
+		},
+		{
+			name: "repeat from the beginning, three times",
+			tab: newFormTab(3,
+				MeasureForm{Measure: 1, RepeatEnd: 3}),
+			expected: []int{0, 1, 0, 1, 0, 1, 2},
+		},
+		{
+			name: "first and second endings",
+			tab: newFormTab(5,
+				MeasureForm{Measure: 0, RepeatStart: true},
+				MeasureForm{Measure: 2, Ending: 1, RepeatEnd: 2},
+				MeasureForm{Measure: 3, Ending: 2}),
+			expected: []int{0, 1, 2, 0, 1, 3, 4},
+		},
+		{
+			name: "D.C. al Fine",
+			tab: newFormTab(4,
+				MeasureForm{Measure: 1, Fine: true},
+				MeasureForm{Measure: 3, Jump: JumpDCalFine}),
+			expected: []int{0, 1, 2, 3, 0, 1},
+		},
+		{
+			name: "D.S. al Coda skips repeats after the jump",
+			tab: newFormTab(6,
+				MeasureForm{Measure: 1, Segno: true, RepeatStart: true},
+				MeasureForm{Measure: 2, ToCoda: true, RepeatEnd: 2},
+				MeasureForm{Measure: 3, Jump: JumpDSalCoda},
+				MeasureForm{Measure: 5, Coda: true}),
+			expected: []int{0, 1, 2, 1, 2, 3, 1, 2, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tab.PlaybackOrder(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected order %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnrolled(t *testing.T) {
+	tab := newFormTab(2, MeasureForm{Measure: 0, RepeatEnd: 2})
+	tab.SetFret(0, 3, 5)
+	tab.SetChord(3, "A")
+
+	unrolled := tab.Unrolled()
+	if unrolled.Measures != 3 || unrolled.GetMeasureCount() != 3 {
+		t.Fatalf("Expected 3 measures, got %d/%d", unrolled.Measures, unrolled.GetMeasureCount())
+	}
+	if unrolled.HasForm() {
+		t.Error("Expected form markings to be removed")
+	}
+	if unrolled.Content[0][3] != '5' || unrolled.Content[0][MeasureLength+3] != '5' {
+		t.Errorf("Expected repeated note in both passes, got %q", unrolled.Content[0])
+	}
+	if unrolled.ChordAt(MeasureLength+3) != "A" {
+		t.Error("Expected chord to be repeated in the second pass")
+	}
+}
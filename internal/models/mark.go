@@ -0,0 +1,57 @@
+// internal/models/mark.go
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Mark is a cell of the tab named with a letter, to return to later
+type Mark struct {
+	Name     string `json:"name"`
+	String   int    `json:"string"`
+	Position int    `json:"position"`
+}
+
+// MarkAt returns the mark with the given name
+func (t *Tab) MarkAt(name string) (Mark, bool) {
+	for _, mark := range t.Marks {
+		if mark.Name == name {
+			return mark, true
+		}
+	}
+	return Mark{}, false
+}
+
+// SetMark names a cell, moving the mark if it was set before. Marks are
+// kept ordered by name.
+func (t *Tab) SetMark(name string, str, pos int) {
+	marks := []Mark{{Name: name, String: str, Position: pos}}
+	for _, mark := range t.Marks {
+		if mark.Name != name {
+			marks = append(marks, mark)
+		}
+	}
+	sort.Slice(marks, func(i, j int) bool { return marks[i].Name < marks[j].Name })
+	t.Marks = marks
+	t.UpdatedAt = time.Now()
+}
+
+// shiftMarks moves marks in columns [pos, end) by delta columns, like
+// shiftAnnotations. Marks on a deleted column or pushed to end are dropped.
+func shiftMarks(marks []Mark, pos, end, delta int) []Mark {
+	var result []Mark
+	for _, mark := range marks {
+		if mark.Position >= pos && mark.Position < end {
+			if delta < 0 && mark.Position == pos {
+				continue
+			}
+			mark.Position += delta
+			if mark.Position >= end {
+				continue
+			}
+		}
+		result = append(result, mark)
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package models
+
+import "testing"
+
+func TestMarksFollowEdits(t *testing.T) {
+	tab := newFormTab(3)
+	tab.SetMark("b", 2, 20)
+	tab.SetMark("a", 0, 3)
+	tab.SetMark("c", 5, 40)
+	tab.SetMark("a", 1, 4) // Moves the mark
+
+	if len(tab.Marks) != 3 || tab.Marks[0].Name != "a" || tab.Marks[1].Name != "b" {
+		t.Fatalf("Expected marks a, b, c in order, got %+v", tab.Marks)
+	}
+	if mark, ok := tab.MarkAt("a"); !ok || mark.String != 1 || mark.Position != 4 {
+		t.Errorf("Expected mark a on string 1 at column 4, got %+v", mark)
+	}
+
+	// Inserting a measure before the second moves b and c along with it
+	tab.InsertMeasure(1)
+	if mark, _ := tab.MarkAt("b"); mark.Position != 36 {
+		t.Errorf("Expected mark b at column 36, got %d", mark.Position)
+	}
+
+	// Deleting the measure of a mark removes it
+	tab.DeleteMeasure(3)
+	if _, ok := tab.MarkAt("c"); ok {
+		t.Error("Expected mark c to go with its measure")
+	}
+
+	// Columns inserted and deleted before a mark shift it within the measure
+	tab.InsertColumn(0, ShiftMeasure)
+	tab.DeleteColumn(32, ShiftMeasure)
+	if mark, _ := tab.MarkAt("a"); mark.Position != 5 {
+		t.Errorf("Expected mark a at column 5, got %d", mark.Position)
+	}
+	if mark, _ := tab.MarkAt("b"); mark.Position != 35 {
+		t.Errorf("Expected mark b at column 35, got %d", mark.Position)
+	}
+}
@@ -0,0 +1,192 @@
+// internal/models/measure.go
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// emptyMeasure is the content of one string in a measure without notes
+var emptyMeasure = strings.Repeat("-", MeasureLength)
+
+// InsertMeasure inserts an empty measure before the measure at index at.
+// An index equal to the measure count appends. The new measure joins the
+// section of the measure before it.
+func (t *Tab) InsertMeasure(at int) {
+	count := t.GetMeasureCount()
+	if at < 0 || at > count {
+		return
+	}
+
+	order := make([]int, 0, count+1)
+	for m := 0; m < count; m++ {
+		if m == at {
+			order = append(order, -1)
+		}
+		order = append(order, m)
+	}
+	if at == count {
+		order = append(order, -1)
+	}
+
+	owner := at - 1
+	if owner < 0 {
+		owner = 0
+	}
+	t.rearrange(order)
+	t.growSections(owner)
+}
+
+// DeleteMeasure removes the measure at the given index together with its
+// chords, lyrics and markings. The last remaining measure is kept.
+func (t *Tab) DeleteMeasure(measure int) {
+	count := t.GetMeasureCount()
+	if count <= 1 || measure < 0 || measure >= count {
+		return
+	}
+
+	order := make([]int, 0, count-1)
+	for m := 0; m < count; m++ {
+		if m != measure {
+			order = append(order, m)
+		}
+	}
+	t.rearrange(order)
+
+	var sections []Section
+	for _, s := range t.Sections {
+		if s.Start > measure {
+			s.Start--
+		}
+		if s.End >= measure {
+			s.End--
+		}
+		if s.End >= s.Start {
+			sections = append(sections, s)
+		}
+	}
+	t.Sections = sections
+}
+
+// DuplicateMeasure inserts a copy of the measure right after it. The copy
+// keeps the notes, chords and lyrics and stays inside the same ending, but
+// repeat signs and jumps are not doubled.
+func (t *Tab) DuplicateMeasure(measure int) {
+	count := t.GetMeasureCount()
+	if measure < 0 || measure >= count {
+		return
+	}
+
+	order := make([]int, 0, count+1)
+	for m := 0; m < count; m++ {
+		order = append(order, m)
+		if m == measure {
+			order = append(order, m)
+		}
+	}
+	t.rearrange(order)
+	t.growSections(measure)
+}
+
+// MoveMeasure moves a measure to a new index, shifting the measures in
+// between. Sections stay on their measure ranges, so a measure moved across
+// a section boundary changes section.
+func (t *Tab) MoveMeasure(from, to int) {
+	count := t.GetMeasureCount()
+	if from < 0 || from >= count || to < 0 || to >= count || from == to {
+		return
+	}
+
+	order := make([]int, 0, count)
+	for m := 0; m < count; m++ {
+		if m != from {
+			order = append(order, m)
+		}
+	}
+	order = append(order[:to], append([]int{from}, order[to:]...)...)
+	t.rearrange(order)
+}
+
+// rearrange rebuilds the content, chord and lyric lanes, form markings and
+// marks from order, which lists for each new measure the old measure it is
+// taken from, or -1 for an empty one. Sections are left to the caller.
+func (t *Tab) rearrange(order []int) {
+	var content [6]strings.Builder
+	var chords, lyrics []Annotation
+	var form []MeasureForm
+	var marks []Mark
+	seen := make(map[int]bool)
+
+	for i, src := range order {
+		for str := 0; str < 6; str++ {
+			start := src * MeasureLength
+			if src >= 0 && start+MeasureLength <= len(t.Content[str]) {
+				content[str].WriteString(t.Content[str][start : start+MeasureLength])
+			} else {
+				content[str].WriteString(emptyMeasure)
+			}
+		}
+		if src < 0 {
+			continue
+		}
+
+		offset := (i - src) * MeasureLength
+		for _, a := range t.Chords {
+			if a.Position/MeasureLength == src {
+				chords = append(chords, Annotation{Position: a.Position + offset, Text: a.Text})
+			}
+		}
+		for _, a := range t.Lyrics {
+			if a.Position/MeasureLength == src {
+				lyrics = append(lyrics, Annotation{Position: a.Position + offset, Text: a.Text})
+			}
+		}
+
+		// A mark stays on the original of a duplicated measure
+		for _, mark := range t.Marks {
+			if mark.Position/MeasureLength == src && !seen[src] {
+				mark.Position += offset
+				marks = append(marks, mark)
+			}
+		}
+
+		f := t.FormAt(src)
+		if seen[src] {
+			f = MeasureForm{Ending: f.Ending}
+		}
+		f.Measure = i
+		if !f.IsEmpty() {
+			form = append(form, f)
+		}
+		seen[src] = true
+	}
+
+	for str := 0; str < 6; str++ {
+		t.Content[str] = content[str].String()
+	}
+	t.Chords = chords
+	t.Lyrics = lyrics
+	t.Form = form
+	sort.Slice(marks, func(i, j int) bool { return marks[i].Name < marks[j].Name })
+	t.Marks = marks
+	t.Measures = len(order)
+	t.UpdatedAt = time.Now()
+}
+
+// growSections shifts sections for a measure inserted right after owner
+// (or before it, when owner is the first measure). The section containing
+// owner grows to take in the new measure.
+func (t *Tab) growSections(owner int) {
+	sections := make([]Section, len(t.Sections))
+	for i, s := range t.Sections {
+		if s.Start > owner {
+			s.Start++
+		}
+		if s.End >= owner {
+			s.End++
+		}
+		sections[i] = s
+	}
+	t.Sections = sections
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+// measureTab builds a tab whose measure i starts with fret i on the high e
+// string and carries the chord "Ci"
+func measureTab(count int) *Tab {
+	tab := newFormTab(count)
+	for m := 0; m < count; m++ {
+		tab.SetFret(0, m*MeasureLength, m)
+		tab.SetChord(m*MeasureLength, "C"+string(rune('0'+m)))
+	}
+	return tab
+}
+
+// firstFrets returns the fret at the start of each measure, -1 if empty
+func firstFrets(tab *Tab) []int {
+	var frets []int
+	for m := 0; m < tab.GetMeasureCount(); m++ {
+		fret := -1
+		if note, ok := tab.NoteAt(0, m*MeasureLength); ok {
+			fret = note.Fret
+		}
+		frets = append(frets, fret)
+	}
+	return frets
+}
+
+func TestMeasureOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		apply    func(tab *Tab)
+		expected []int
+	}{
+		{"insert before", func(tab *Tab) { tab.InsertMeasure(1) }, []int{0, -1, 1, 2}},
+		{"insert at end", func(tab *Tab) { tab.InsertMeasure(3) }, []int{0, 1, 2, -1}},
+		{"delete", func(tab *Tab) { tab.DeleteMeasure(1) }, []int{0, 2}},
+		{"duplicate", func(tab *Tab) { tab.DuplicateMeasure(0) }, []int{0, 0, 1, 2}},
+		{"move right", func(tab *Tab) { tab.MoveMeasure(0, 1) }, []int{1, 0, 2}},
+		{"move left", func(tab *Tab) { tab.MoveMeasure(2, 1) }, []int{0, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		tab := measureTab(3)
+		tt.apply(tab)
+
+		if got := firstFrets(tab); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: expected measures %v, got %v", tt.name, tt.expected, got)
+		}
+		if tab.Measures != len(tt.expected) {
+			t.Errorf("%s: expected Measures %d, got %d", tt.name, len(tt.expected), tab.Measures)
+		}
+		for str, line := range tab.Content {
+			if len(line) != len(tt.expected)*MeasureLength {
+				t.Errorf("%s: string %d has length %d", tt.name, str, len(line))
+			}
+		}
+		for _, chord := range tab.Chords {
+			note, ok := tab.NoteAt(0, chord.Position)
+			if !ok || chord.Text != "C"+string(rune('0'+note.Fret)) {
+				t.Errorf("%s: chord %q did not move with its measure", tt.name, chord.Text)
+			}
+		}
+	}
+}
+
+func TestMeasureOperationsKeepSectionsAndForm(t *testing.T) {
+	tab := measureTab(4)
+	tab.MarkSection(0, "Verse")
+	tab.MarkSection(2, "Chorus")
+	tab.SetForm(MeasureForm{Measure: 3, RepeatEnd: 2, Ending: 1})
+
+	tab.InsertMeasure(2) // Joins the verse
+	expected := []Section{{Name: "Verse", Start: 0, End: 2}, {Name: "Chorus", Start: 3, End: 4}}
+	if !reflect.DeepEqual(tab.Sections, expected) {
+		t.Errorf("After insert: expected %v, got %v", expected, tab.Sections)
+	}
+	if form := tab.FormAt(4); form.RepeatEnd != 2 {
+		t.Errorf("Expected the repeat to move to measure 5, got %+v", tab.Form)
+	}
+
+	tab.DuplicateMeasure(4)
+	if form := tab.FormAt(5); form.Ending != 1 || form.RepeatEnd != 0 {
+		t.Errorf("Expected the copy to stay in the ending without the repeat, got %+v", form)
+	}
+
+	tab.DeleteMeasure(0)
+	expected = []Section{{Name: "Verse", Start: 0, End: 1}, {Name: "Chorus", Start: 2, End: 4}}
+	if !reflect.DeepEqual(tab.Sections, expected) {
+		t.Errorf("After delete: expected %v, got %v", expected, tab.Sections)
+	}
+}
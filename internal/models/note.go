@@ -0,0 +1,246 @@
+// internal/models/note.go
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxFret is the highest fret number supported by the tab model
+const MaxFret = 24
+
+// Note is a single fretted note read from the tab content
+type Note struct {
+	String   int // 0 = highest string, matching Content order
+	Position int // Column of the first digit
+	Fret     int
+	Width    int // Number of columns the fret number occupies
+}
+
+// NoteNames holds the sharp spelling of each pitch class, starting at C
+var NoteNames = [12]string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+
+// StandardTuningMIDI holds the MIDI note numbers of standard tuning (e B G D A E)
+var StandardTuningMIDI = [6]int{64, 59, 55, 50, 45, 40}
+
+// ParsePitchClass converts a note name such as "E", "f#" or "Bb" into a pitch class (0-11)
+func ParsePitchClass(name string) (int, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, false
+	}
+
+	base := map[byte]int{'C': 0, 'D': 2, 'E': 4, 'F': 5, 'G': 7, 'A': 9, 'B': 11}
+	pc, ok := base[strings.ToUpper(name[:1])[0]]
+	if !ok {
+		return 0, false
+	}
+
+	for _, accidental := range name[1:] {
+		switch accidental {
+		case '#':
+			pc++
+		case 'b':
+			pc--
+		default:
+			return 0, false
+		}
+	}
+
+	return (pc%12 + 12) % 12, true
+}
+
+// OpenStringMIDI returns the MIDI note number of each open string.
+// Tuning names carry no octave, so each string is placed in the octave
+// closest to the matching string of standard tuning.
+func (t *Tab) OpenStringMIDI() [6]int {
+	var notes [6]int
+	for i := 0; i < 6; i++ {
+		standard := StandardTuningMIDI[i]
+		pc, ok := ParsePitchClass(t.Tuning[i])
+		if !ok {
+			notes[i] = standard
+			continue
+		}
+
+		diff := (pc - standard%12 + 12) % 12
+		if diff > 6 {
+			diff -= 12
+		}
+		notes[i] = standard + diff
+	}
+	return notes
+}
+
+// CapoStringMIDI returns the MIDI note number each string sounds at fret 0
+// of the tab, which is the capo fret when a capo is used
+func (t *Tab) CapoStringMIDI() [6]int {
+	notes := t.OpenStringMIDI()
+	for i := range notes {
+		notes[i] += t.Capo
+	}
+	return notes
+}
+
+// MIDINote returns the MIDI note number sounded by a fret on the given
+// string, counting the fret from the capo
+func (t *Tab) MIDINote(str, fret int) int {
+	return t.CapoStringMIDI()[str] + fret
+}
+
+// isDigit reports whether the byte is an ASCII digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parseNoteAt reads the fret number starting at pos on the given line.
+// Runs of two digits form a single fret (e.g. "12") when the result is
+// within the fretboard range, following the usual ASCII tab convention.
+func parseNoteAt(line string, pos int) (fret, width int, ok bool) {
+	if pos < 0 || pos >= len(line) || !isDigit(line[pos]) {
+		return 0, 0, false
+	}
+
+	fret = int(line[pos] - '0')
+	width = 1
+	if pos+1 < len(line) && isDigit(line[pos+1]) {
+		if twoDigit, err := strconv.Atoi(line[pos : pos+2]); err == nil && twoDigit <= MaxFret {
+			fret = twoDigit
+			width = 2
+		}
+	}
+
+	return fret, width, true
+}
+
+// StringNotes returns the notes on a single string in column order
+func (t *Tab) StringNotes(str int) []Note {
+	return lineNotes(str, t.Content[str])
+}
+
+// lineNotes reads the notes of a string's line in column order
+func lineNotes(str int, line string) []Note {
+	var notes []Note
+	for pos := 0; pos < len(line); {
+		fret, width, ok := parseNoteAt(line, pos)
+		if !ok {
+			pos++
+			continue
+		}
+		notes = append(notes, Note{String: str, Position: pos, Fret: fret, Width: width})
+		pos += width
+	}
+	return notes
+}
+
+// Notes returns every note in the tab ordered by position, then string
+func (t *Tab) Notes() []Note {
+	var notes []Note
+	var perString [6][]Note
+	for str := 0; str < 6; str++ {
+		perString[str] = t.StringNotes(str)
+	}
+
+	var next [6]int
+	for {
+		best := -1
+		for str := 0; str < 6; str++ {
+			if next[str] >= len(perString[str]) {
+				continue
+			}
+			if best == -1 || perString[str][next[str]].Position < perString[best][next[best]].Position {
+				best = str
+			}
+		}
+		if best == -1 {
+			return notes
+		}
+		notes = append(notes, perString[best][next[best]])
+		next[best]++
+	}
+}
+
+// NoteAt returns the note covering the given cell, if any
+func (t *Tab) NoteAt(str, pos int) (Note, bool) {
+	for _, note := range t.StringNotes(str) {
+		if pos >= note.Position && pos < note.Position+note.Width {
+			return note, true
+		}
+	}
+	return Note{}, false
+}
+
+// SetFret writes a fret number at the given position, overwriting the
+// cells it occupies. It returns false if the fret does not fit.
+func (t *Tab) SetFret(str, pos, fret int) bool {
+	if fret < 0 || fret > MaxFret {
+		return false
+	}
+
+	digits := strconv.Itoa(fret)
+	line := []byte(t.Content[str])
+	if pos < 0 || pos+len(digits) > len(line) {
+		return false
+	}
+
+	copy(line[pos:], digits)
+	t.Content[str] = string(line)
+	t.UpdatedAt = time.Now()
+	return true
+}
+
+// PlaceFret writes a fret at pos on the string, replacing the note there
+// and a note in the next column that a two-digit fret needs room for. It
+// returns false, leaving the string unchanged, if the fret would run into
+// a neighbouring fret number or off the fretboard.
+func (t *Tab) PlaceFret(str, pos, fret int) bool {
+	if fret < 0 || fret > MaxFret {
+		return false
+	}
+	before := t.Content[str]
+	if note, ok := t.NoteAt(str, pos); ok {
+		t.clearNote(note)
+	}
+	if fret >= 10 {
+		if note, ok := t.NoteAt(str, pos+1); ok {
+			t.clearNote(note)
+		}
+	}
+	if !t.fretFits(str, pos, fret) {
+		t.Content[str] = before
+		return false
+	}
+	return t.SetFret(str, pos, fret)
+}
+
+// RemoveNote replaces the note covering the cell with rests. It returns
+// false if there is no note there.
+func (t *Tab) RemoveNote(str, pos int) bool {
+	note, ok := t.NoteAt(str, pos)
+	if !ok {
+		return false
+	}
+	t.clearNote(note)
+	t.UpdatedAt = time.Now()
+	return true
+}
+
+// SetColumn writes a chord shape at pos, one fret per string, where a
+// negative fret leaves the string silent. Notes already in the column are
+// replaced as with PlaceFret. It returns the number of strings whose fret
+// did not fit.
+func (t *Tab) SetColumn(pos int, frets [6]int) int {
+	failed := 0
+	for str, fret := range frets {
+		if fret < 0 {
+			t.RemoveNote(str, pos)
+			continue
+		}
+		if !t.PlaceFret(str, pos, fret) {
+			failed++
+		}
+	}
+	t.UpdatedAt = time.Now()
+	return failed
+}
@@ -0,0 +1,155 @@
+// internal/models/playability.go
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// WarningKind is the kind of problem the playability check found
+type WarningKind int
+
+const (
+	WarnStretch WarningKind = iota // Chord spans more frets than a hand can reach
+	WarnJump                       // Position shift too fast for the tempo
+	WarnRange                      // Fret past the end of the fretboard
+)
+
+func (k WarningKind) String() string {
+	switch k {
+	case WarnStretch:
+		return "stretch"
+	case WarnJump:
+		return "jump"
+	case WarnRange:
+		return "range"
+	}
+	return "unknown"
+}
+
+// Warning is a passage that is awkward or impossible to play
+type Warning struct {
+	Kind     WarningKind
+	Position int // Column the warning refers to
+	String   int // String the warning refers to, or -1 for the whole column
+	Message  string
+}
+
+// DefaultMaxStretch is the widest chord span, in frets between the lowest
+// and highest fretted note, that is not reported
+const DefaultMaxStretch = 4
+
+// Position shifts shorter than minJumpFrets are never reported. Longer ones
+// need a base time to lift and place the hand plus a little per fret.
+const (
+	minJumpFrets    = 5
+	jumpBaseTime    = 100 * time.Millisecond
+	jumpTimePerFret = 15 * time.Millisecond
+)
+
+// Playability checks the tab for passages that are awkward or impossible
+// to play: chords spanning more than maxStretch frets, position shifts
+// too fast for the tempo and digits that would be a fret past the last.
+// Two notes on one string in one column are left out on purpose: each
+// string is a single line of cells, so a column holds one note of it at
+// most. Warnings are ordered by column.
+func (t *Tab) Playability(maxStretch int) []Warning {
+	warnings := append(t.rangeWarnings(), CheckNotes(t.Notes(), t.Tempo, maxStretch)...)
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return warnings[i].Position < warnings[j].Position
+	})
+	return warnings
+}
+
+// rangeWarnings finds runs of two digits that would be a fret past
+// MaxFret; they are read as two notes, which is rarely what was meant
+func (t *Tab) rangeWarnings() []Warning {
+	var warnings []Warning
+	for str, line := range t.Content {
+		for pos := 0; pos+1 < len(line); pos++ {
+			if !isDigit(line[pos]) || !isDigit(line[pos+1]) {
+				continue
+			}
+			if _, width, _ := parseNoteAt(line, pos); width == 1 {
+				warnings = append(warnings, Warning{
+					Kind:     WarnRange,
+					Position: pos,
+					String:   str,
+					Message: fmt.Sprintf("%s is past fret %d, so it is read as frets %c and %c",
+						line[pos:pos+2], MaxFret, line[pos], line[pos+1]),
+				})
+			} else {
+				pos++ // Skip the second digit of a valid two-digit fret
+			}
+		}
+	}
+	return warnings
+}
+
+// CheckNotes looks for stretches and fast position shifts in notes ordered
+// by column, played at the given tempo
+func CheckNotes(notes []Note, tempo, maxStretch int) []Warning {
+	if tempo <= 0 {
+		tempo = 120
+	}
+	column := time.Minute / time.Duration(tempo*4) // Each column is a 16th note
+
+	var warnings []Warning
+	lastPosition, lastHand := -1, 0
+	for i := 0; i < len(notes); {
+		j := i
+		for j < len(notes) && notes[j].Position == notes[i].Position {
+			j++
+		}
+		group := notes[i:j]
+		pos := group[0].Position
+		i = j
+
+		low, high := 0, 0
+		for _, note := range group {
+			if note.Fret == 0 {
+				continue // Open strings need no finger
+			}
+			if low == 0 || note.Fret < low {
+				low = note.Fret
+			}
+			if note.Fret > high {
+				high = note.Fret
+			}
+		}
+		if low == 0 {
+			continue // Open strings leave the hand where it was
+		}
+
+		if span := high - low; span > maxStretch {
+			warnings = append(warnings, Warning{
+				Kind:     WarnStretch,
+				Position: pos,
+				String:   -1,
+				Message:  fmt.Sprintf("Chord spans %d frets (%d-%d), more than %d", span, low, high, maxStretch),
+			})
+		}
+
+		// The hand position is the lowest fretted note of the column
+		if lastPosition >= 0 {
+			distance := low - lastHand
+			if distance < 0 {
+				distance = -distance
+			}
+			available := time.Duration(pos-lastPosition) * column
+			needed := jumpBaseTime + time.Duration(distance)*jumpTimePerFret
+			if distance >= minJumpFrets && available < needed {
+				warnings = append(warnings, Warning{
+					Kind:     WarnJump,
+					Position: pos,
+					String:   -1,
+					Message: fmt.Sprintf("Jump of %d frets (%d to %d) in %dms at %d BPM",
+						distance, lastHand, low, available.Milliseconds(), tempo),
+				})
+			}
+		}
+		lastPosition, lastHand = pos, low
+	}
+	return warnings
+}
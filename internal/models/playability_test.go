@@ -0,0 +1,54 @@
+package models
+
+import "testing"
+
+// warningKinds counts the warnings of each kind
+func warningKinds(warnings []Warning) map[WarningKind]int {
+	kinds := make(map[WarningKind]int)
+	for _, w := range warnings {
+		kinds[w.Kind]++
+	}
+	return kinds
+}
+
+func TestPlayability(t *testing.T) {
+	tab := NewEmptyTab("Awkward")
+	tab.Tempo = 120
+	tab.SetColumn(0, [6]int{-1, -1, 7, 2, -1, -1})   // Spans 5 frets
+	tab.SetColumn(4, [6]int{-1, -1, 2, 0, 3, -1})    // Open strings are free
+	tab.SetColumn(5, [6]int{15, -1, -1, -1, -1, -1}) // 13 frets up in one 16th
+	tab.SetColumn(12, [6]int{3, -1, -1, -1, -1, -1}) // 12 frets down in 7 16ths
+	tab.Content[5] = "--------30" + tab.Content[5][10:]
+
+	warnings := tab.Playability(DefaultMaxStretch)
+	kinds := warningKinds(warnings)
+	if kinds[WarnStretch] != 1 || kinds[WarnJump] != 1 || kinds[WarnRange] != 1 {
+		t.Fatalf("Expected one stretch, jump and range warning, got %+v", warnings)
+	}
+	for _, w := range warnings {
+		switch w.Kind {
+		case WarnStretch:
+			if w.Position != 0 {
+				t.Errorf("Expected the stretch at column 0, got %d", w.Position)
+			}
+		case WarnJump:
+			if w.Position != 5 {
+				t.Errorf("Expected the jump at column 5, got %d", w.Position)
+			}
+		case WarnRange:
+			if w.Position != 8 || w.String != 5 {
+				t.Errorf("Expected the range warning at column 8 of the low E, got %+v", w)
+			}
+		}
+	}
+
+	if kinds := warningKinds(tab.Playability(5)); kinds[WarnStretch] != 0 {
+		t.Error("Expected a 5 fret span to be allowed with a stretch of 5")
+	}
+
+	// The same jump is fine at a slow tempo
+	tab.Tempo = 30
+	if kinds := warningKinds(tab.Playability(DefaultMaxStretch)); kinds[WarnJump] != 0 {
+		t.Error("Expected no jump warning at 30 BPM")
+	}
+}
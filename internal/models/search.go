@@ -0,0 +1,110 @@
+// internal/models/search.go
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SearchTerm matches one note of a search: a fret number, or a note name
+// found by pitch on whichever string plays it
+type SearchTerm struct {
+	Fret       int
+	PitchClass int
+	ByPitch    bool
+}
+
+// SearchMatch is a run of note columns found by a search
+type SearchMatch struct {
+	Start int    // Column of the first matching note
+	End   int    // Last column covered by the matching notes
+	Notes []Note // The notes that matched, column by column
+}
+
+// Contains reports whether the cell belongs to one of the matching notes
+func (m SearchMatch) Contains(str, pos int) bool {
+	for _, note := range m.Notes {
+		if note.String == str && pos >= note.Position && pos < note.Position+note.Width {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSearch reads a search query: frets ("7") and note names ("C#",
+// "Bb") separated by spaces or dashes. Several terms, such as "5-7-8",
+// find that run of notes in successive note columns.
+func ParseSearch(query string) ([]SearchTerm, bool) {
+	fields := strings.FieldsFunc(query, func(r rune) bool {
+		return r == ' ' || r == '-' || r == ','
+	})
+	if len(fields) == 0 {
+		return nil, false
+	}
+
+	terms := make([]SearchTerm, 0, len(fields))
+	for _, field := range fields {
+		if fret, err := strconv.Atoi(field); err == nil {
+			if fret < 0 || fret > MaxFret {
+				return nil, false
+			}
+			terms = append(terms, SearchTerm{Fret: fret})
+			continue
+		}
+		pc, ok := ParsePitchClass(field)
+		if !ok {
+			return nil, false
+		}
+		terms = append(terms, SearchTerm{PitchClass: pc, ByPitch: true})
+	}
+	return terms, true
+}
+
+// matches reports whether a note of the tab is the one the term asks for
+func (t *Tab) matches(term SearchTerm, note Note) bool {
+	if term.ByPitch {
+		return t.MIDINote(note.String, note.Fret)%12 == term.PitchClass
+	}
+	return note.Fret == term.Fret
+}
+
+// Search returns every run of successive note columns whose notes match
+// the terms one by one, in column order. Rests between the columns are
+// skipped, so a lick is found however it is spaced out.
+func (t *Tab) Search(terms []SearchTerm) []SearchMatch {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var columns [][]Note
+	for _, note := range t.Notes() {
+		if n := len(columns); n > 0 && columns[n-1][0].Position == note.Position {
+			columns[n-1] = append(columns[n-1], note)
+			continue
+		}
+		columns = append(columns, []Note{note})
+	}
+
+	var found []SearchMatch
+	for i := 0; i+len(terms) <= len(columns); i++ {
+		match := SearchMatch{Start: columns[i][0].Position}
+		for k, term := range terms {
+			matched := false
+			for _, note := range columns[i+k] {
+				if t.matches(term, note) {
+					match.Notes = append(match.Notes, note)
+					match.End = max(match.End, note.Position+note.Width-1)
+					matched = true
+				}
+			}
+			if !matched {
+				match.Notes = nil
+				break
+			}
+		}
+		if match.Notes != nil {
+			found = append(found, match)
+		}
+	}
+	return found
+}
@@ -0,0 +1,58 @@
+package models
+
+import "testing"
+
+func TestSearch(t *testing.T) {
+	tab := newFormTab(1)
+	tab.Content[0] = "--5---7-8-------"
+	tab.Content[1] = "--------------12"
+	tab.Content[2] = "5-------------2-"
+
+	starts := func(query string) []int {
+		terms, ok := ParseSearch(query)
+		if !ok {
+			t.Fatalf("ParseSearch(%q) failed", query)
+		}
+		var positions []int
+		for _, match := range tab.Search(terms) {
+			positions = append(positions, match.Start)
+		}
+		return positions
+	}
+
+	if got := starts("5"); len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Errorf("Expected fret 5 at columns 0 and 2, got %v", got)
+	}
+	// A on the high e string (fret 5) and on the G string (fret 2)
+	if got := starts("a"); len(got) != 2 || got[0] != 2 || got[1] != 14 {
+		t.Errorf("Expected A at columns 2 and 14, got %v", got)
+	}
+	// The lick skips the rests between its notes
+	if got := starts("5-7-8"); len(got) != 1 || got[0] != 2 {
+		t.Errorf("Expected the lick at column 2, got %v", got)
+	}
+	if got := starts("8 A"); len(got) != 1 || got[0] != 8 {
+		t.Errorf("Expected the run at column 8, got %v", got)
+	}
+	if got := starts("7 5"); len(got) != 0 {
+		t.Errorf("Expected no match, got %v", got)
+	}
+
+	terms, _ := ParseSearch("A")
+	match := tab.Search(terms)[1]
+	if !match.Contains(2, 14) || match.Contains(1, 14) {
+		t.Errorf("Expected only the A on the G string to match, got %+v", match.Notes)
+	}
+
+	// Pitch follows the tuning: fret 5 on a D string is G
+	tab.Tuning[0] = "D"
+	if got := starts("G"); len(got) != 1 || got[0] != 2 {
+		t.Errorf("Expected G at column 2 in the new tuning, got %v", got)
+	}
+
+	for _, query := range []string{"", "25", "H", "5 x"} {
+		if _, ok := ParseSearch(query); ok {
+			t.Errorf("Expected ParseSearch(%q) to fail", query)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// internal/models/section.go
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Section is a named part of the song ("Intro", "Verse 1") covering a range
+// of measures
+type Section struct {
+	Name  string `json:"name"`
+	Start int    `json:"start"` // First measure (0-based)
+	End   int    `json:"end"`   // Last measure (inclusive)
+}
+
+// RehearsalLetter returns the rehearsal mark for the i-th section:
+// A, B, ... Z, AA, BB, ...
+func RehearsalLetter(i int) string {
+	letter := string(rune('A' + i%26))
+	mark := letter
+	for n := i / 26; n > 0; n-- {
+		mark += letter
+	}
+	return mark
+}
+
+// SectionAt returns the index of the section containing the measure, or -1
+func (t *Tab) SectionAt(measure int) int {
+	for i, s := range t.Sections {
+		if measure >= s.Start && measure <= s.End {
+			return i
+		}
+	}
+	return -1
+}
+
+// MarkSection starts a section named name at the given measure. It runs
+// until the next section starts, or to the end of the tab, and shortens
+// any section it starts inside of.
+func (t *Tab) MarkSection(measure int, name string) {
+	if measure < 0 || measure >= t.GetMeasureCount() {
+		return
+	}
+
+	end := t.GetMeasureCount() - 1
+	var sections []Section
+	for _, s := range t.Sections {
+		switch {
+		case s.Start == measure:
+			// Replaced by the new section, which keeps its range
+			end = s.End
+			continue
+		case s.Start < measure && s.End >= measure:
+			s.End = measure - 1
+		case s.Start > measure && s.Start-1 < end:
+			end = s.Start - 1
+		}
+		sections = append(sections, s)
+	}
+
+	sections = append(sections, Section{Name: name, Start: measure, End: end})
+	sort.Slice(sections, func(i, j int) bool { return sections[i].Start < sections[j].Start })
+	t.Sections = sections
+	t.UpdatedAt = time.Now()
+}
+
+// RemoveSection deletes the section containing the measure. The previous
+// section, if it ends right before, grows to cover the freed measures.
+func (t *Tab) RemoveSection(measure int) {
+	idx := t.SectionAt(measure)
+	if idx == -1 {
+		return
+	}
+
+	removed := t.Sections[idx]
+	sections := make([]Section, 0, len(t.Sections)-1)
+	sections = append(sections, t.Sections[:idx]...)
+	sections = append(sections, t.Sections[idx+1:]...)
+	if idx > 0 && sections[idx-1].End == removed.Start-1 {
+		sections[idx-1].End = removed.End
+	}
+	t.Sections = sections
+	t.UpdatedAt = time.Now()
+}
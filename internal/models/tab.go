@@ -6,16 +6,22 @@ import (
 )
 
 type Tab struct {
-	ID            int       `json:"id" db:"id"`
-	Name          string    `json:"name" db:"name"`
-	Artist        string    `json:"artist" db:"artist"`
-	Content       [6]string `json:"content" db:"content"` // 6 strings - now supports variable length
-	Tuning        [6]string `json:"tuning" db:"tuning"`   // E A D G B e
-	Tempo         int       `json:"tempo" db:"tempo"`
-	TimeSignature string    `json:"time_signature" db:"time_signature"`
-	Measures      int       `json:"measures" db:"measures"` // Number of measures
-	CreatedAt     time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	ID            int           `json:"id" db:"id"`
+	Name          string        `json:"name" db:"name"`
+	Artist        string        `json:"artist" db:"artist"`
+	Content       [6]string     `json:"content" db:"content"` // 6 strings - now supports variable length
+	Tuning        [6]string     `json:"tuning" db:"tuning"`   // E A D G B e
+	Capo          int           `json:"capo" db:"capo"`       // Capo fret, 0 for none; frets are written relative to it
+	Tempo         int           `json:"tempo" db:"tempo"`
+	TimeSignature string        `json:"time_signature" db:"time_signature"`
+	Measures      int           `json:"measures" db:"measures"` // Number of measures
+	Chords        []Annotation  `json:"chords" db:"chords"`     // Chord symbols above the staff
+	Lyrics        []Annotation  `json:"lyrics" db:"lyrics"`     // Lyric syllables below the staff
+	Sections      []Section     `json:"sections" db:"sections"` // Named song sections over measure ranges
+	Form          []MeasureForm `json:"form" db:"form"`         // Repeats, endings and jumps per measure
+	Marks         []Mark        `json:"marks" db:"marks"`       // Named cells to jump back to
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at" db:"updated_at"`
 }
 
 func NewEmptyTab(name string) *Tab {
@@ -57,6 +63,13 @@ func NewTestTab(name string) *Tab {
 	return tab
 }
 
+// Annotation is a text label, such as a chord symbol or lyric syllable,
+// anchored to a tab column
+type Annotation struct {
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+}
+
 type Position struct {
 	String   int
 	Position int
@@ -78,6 +91,8 @@ const (
 	EditNormal EditMode = iota
 	EditInsert
 	EditSelect
+	EditChord
+	EditLyric
 )
 
 type PlaybackState struct {
@@ -98,29 +113,14 @@ type SessionState struct {
 // Helper methods for Tab
 const MeasureLength = 16 // Characters per measure
 
-// AddMeasure adds a new measure to the tab
+// AddMeasure adds a new measure to the end of the tab
 func (t *Tab) AddMeasure() {
-	measureLine := "----------------"
-	for i := 0; i < 6; i++ {
-		t.Content[i] += measureLine
-	}
-	t.Measures++
-	t.UpdatedAt = time.Now()
+	t.InsertMeasure(t.GetMeasureCount())
 }
 
 // RemoveMeasure removes the last measure from the tab
 func (t *Tab) RemoveMeasure() {
-	if t.Measures <= 1 {
-		return // Keep at least one measure
-	}
-
-	for i := 0; i < 6; i++ {
-		if len(t.Content[i]) >= MeasureLength {
-			t.Content[i] = t.Content[i][:len(t.Content[i])-MeasureLength]
-		}
-	}
-	t.Measures--
-	t.UpdatedAt = time.Now()
+	t.DeleteMeasure(t.GetMeasureCount() - 1)
 }
 
 // GetMeasureCount returns the number of measures based on content length
@@ -138,3 +138,56 @@ func (t *Tab) GetTotalLength() int {
 	}
 	return len(t.Content[0])
 }
+
+// annotationAt returns the text anchored at pos in the given lane
+func annotationAt(lane []Annotation, pos int) string {
+	for _, a := range lane {
+		if a.Position == pos {
+			return a.Text
+		}
+	}
+	return ""
+}
+
+// setAnnotation sets, replaces or (with empty text) removes the text at pos,
+// keeping the lane ordered by position
+func setAnnotation(lane []Annotation, pos int, text string) []Annotation {
+	result := make([]Annotation, 0, len(lane)+1)
+	inserted := text == ""
+	for _, a := range lane {
+		if a.Position == pos {
+			continue
+		}
+		if !inserted && a.Position > pos {
+			result = append(result, Annotation{Position: pos, Text: text})
+			inserted = true
+		}
+		result = append(result, a)
+	}
+	if !inserted {
+		result = append(result, Annotation{Position: pos, Text: text})
+	}
+	return result
+}
+
+// ChordAt returns the chord symbol anchored at the given column
+func (t *Tab) ChordAt(pos int) string {
+	return annotationAt(t.Chords, pos)
+}
+
+// SetChord anchors a chord symbol at the given column; empty text removes it
+func (t *Tab) SetChord(pos int, text string) {
+	t.Chords = setAnnotation(t.Chords, pos, text)
+	t.UpdatedAt = time.Now()
+}
+
+// LyricAt returns the lyric syllable anchored at the given column
+func (t *Tab) LyricAt(pos int) string {
+	return annotationAt(t.Lyrics, pos)
+}
+
+// SetLyric anchors a lyric syllable at the given column; empty text removes it
+func (t *Tab) SetLyric(pos int, text string) {
+	t.Lyrics = setAnnotation(t.Lyrics, pos, text)
+	t.UpdatedAt = time.Now()
+}
@@ -0,0 +1,215 @@
+// internal/models/transpose.go
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Selection is a block of strings and columns, both inclusive
+type Selection struct {
+	FirstString int
+	LastString  int
+	Start       int
+	End         int
+}
+
+// WholeTab returns a selection covering every string and column
+func (t *Tab) WholeTab() Selection {
+	return Selection{FirstString: 0, LastString: 5, Start: 0, End: t.GetTotalLength() - 1}
+}
+
+// Contains reports whether the cell lies inside the selection
+func (s Selection) Contains(str, pos int) bool {
+	return str >= s.FirstString && str <= s.LastString && pos >= s.Start && pos <= s.End
+}
+
+// SelectedNotes returns the notes starting inside the selection
+func (t *Tab) SelectedNotes(sel Selection) []Note {
+	var notes []Note
+	for _, note := range t.Notes() {
+		if sel.Contains(note.String, note.Position) {
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+// placement is a candidate string and fret for a relocated note
+type placement struct {
+	str  int
+	fret int
+}
+
+// Transpose shifts the selected notes by the given number of semitones.
+// A note stays on its string when the new fret is on the fretboard and
+// otherwise moves to the nearest string that can play the pitch. Notes
+// that fit nowhere are left unchanged; their number is returned. When the
+// selection spans all strings, chord symbols in it are transposed as well.
+func (t *Tab) Transpose(sel Selection, semitones int) int {
+	open := t.OpenStringMIDI()
+	failed := t.relocate(t.SelectedNotes(sel), func(note Note) []placement {
+		pitch := open[note.String] + note.Fret + semitones
+		candidates := []placement{{note.String, pitch - open[note.String]}}
+
+		// Nearest strings first, preferring the direction of the shift
+		for distance := 1; distance < 6; distance++ {
+			for _, str := range []int{note.String - distance, note.String + distance} {
+				if semitones < 0 {
+					str = 2*note.String - str
+				}
+				if str >= 0 && str < 6 {
+					candidates = append(candidates, placement{str, pitch - open[str]})
+				}
+			}
+		}
+		return candidates
+	})
+
+	if sel.FirstString == 0 && sel.LastString == 5 {
+		for i, chord := range t.Chords {
+			if chord.Position >= sel.Start && chord.Position <= sel.End {
+				t.Chords[i].Text = TransposeChordSymbol(chord.Text, semitones)
+			}
+		}
+	}
+	return failed
+}
+
+// MoveToString re-fingers the selected notes delta strings lower (positive)
+// or higher (negative) while keeping their pitch. Notes that cannot be
+// played there are left unchanged; their number is returned.
+func (t *Tab) MoveToString(sel Selection, delta int) int {
+	open := t.OpenStringMIDI()
+	return t.relocate(t.SelectedNotes(sel), func(note Note) []placement {
+		str := note.String + delta
+		if str < 0 || str >= 6 {
+			return nil
+		}
+		return []placement{{str, open[note.String] + note.Fret - open[str]}}
+	})
+}
+
+// relocate lifts the notes off the tab and writes each at the first
+// candidate placement that is on the fretboard and free. Notes without a
+// usable placement stay where they were; their number is returned. When
+// another note has been moved into the cell of one that stays, the notes
+// are placed again from the start with the staying notes kept in place.
+func (t *Tab) relocate(notes []Note, candidates func(Note) []placement) int {
+	original := t.Content
+	kept := make(map[Note]bool)
+	for {
+		t.Content = original
+		for _, note := range notes {
+			if !kept[note] {
+				t.clearNote(note)
+			}
+		}
+
+		var failed []Note
+		for _, note := range notes {
+			if kept[note] {
+				continue
+			}
+			placed := false
+			for _, c := range candidates(note) {
+				if t.fretFits(c.str, note.Position, c.fret) {
+					t.SetFret(c.str, note.Position, c.fret)
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				failed = append(failed, note)
+			}
+		}
+
+		blocked := false
+		for _, note := range failed {
+			kept[note] = true
+			if !t.fretFits(note.String, note.Position, note.Fret) {
+				blocked = true
+			}
+		}
+		if !blocked {
+			for _, note := range failed {
+				t.SetFret(note.String, note.Position, note.Fret)
+			}
+			t.UpdatedAt = time.Now()
+			return len(kept)
+		}
+	}
+}
+
+// clearNote replaces the digits of a note with rests
+func (t *Tab) clearNote(note Note) {
+	line := []byte(t.Content[note.String])
+	for i := note.Position; i < note.Position+note.Width && i < len(line); i++ {
+		line[i] = '-'
+	}
+	t.Content[note.String] = string(line)
+}
+
+// fretFits reports whether a fret can be written at pos on the string
+// without overwriting a note or running into a neighbouring fret number
+func (t *Tab) fretFits(str, pos, fret int) bool {
+	if fret < 0 || fret > MaxFret {
+		return false
+	}
+	width := 1
+	if fret >= 10 {
+		width = 2
+	}
+
+	line := t.Content[str]
+	if pos < 0 || pos+width > len(line) {
+		return false
+	}
+	for i := pos; i < pos+width; i++ {
+		if line[i] != '-' {
+			return false
+		}
+	}
+	if pos > 0 && isDigit(line[pos-1]) {
+		return false
+	}
+	return pos+width >= len(line) || !isDigit(line[pos+width])
+}
+
+// flatNames spells each pitch class with flats, for chords written in flats
+var flatNames = [12]string{"C", "Db", "D", "Eb", "E", "F", "Gb", "G", "Ab", "A", "Bb", "B"}
+
+// transposeNote transposes a note name at the start of s, returning the
+// new name and the rest of s. ok is false if s does not start with a note.
+func transposeNote(s string, semitones int) (name, rest string, ok bool) {
+	if s == "" || !strings.ContainsRune("ABCDEFG", rune(s[0])) {
+		return "", s, false
+	}
+	length := 1
+	if len(s) > 1 && (s[1] == '#' || s[1] == 'b') {
+		length = 2
+	}
+
+	pc, _ := ParsePitchClass(s[:length])
+	pc = ((pc+semitones)%12 + 12) % 12
+	if length == 2 && s[1] == 'b' {
+		return flatNames[pc], s[length:], true
+	}
+	return NoteNames[pc], s[length:], true
+}
+
+// TransposeChordSymbol transposes the root and slash bass of a chord
+// symbol such as "F#m7" or "G/B". Text that is not a chord is unchanged.
+func TransposeChordSymbol(symbol string, semitones int) string {
+	root, rest, ok := transposeNote(symbol, semitones)
+	if !ok {
+		return symbol
+	}
+
+	if slash := strings.LastIndex(rest, "/"); slash != -1 {
+		if bass, after, ok := transposeNote(rest[slash+1:], semitones); ok && after == "" {
+			rest = rest[:slash+1] + bass
+		}
+	}
+	return root + rest
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranspose(t *testing.T) {
+	tab := NewEmptyTab("Transpose")
+	tab.SetFret(0, 0, 3)  // G on the high e string
+	tab.SetFret(5, 4, 23) // Falls off the fretboard when raised by 2
+	tab.SetChord(0, "G/B")
+
+	if failed := tab.Transpose(tab.WholeTab(), 2); failed != 0 {
+		t.Errorf("Expected every note to be transposed, %d failed", failed)
+	}
+
+	if note, ok := tab.NoteAt(0, 0); !ok || note.Fret != 5 {
+		t.Errorf("Expected fret 5 on the high e string, got %+v", note)
+	}
+	if _, ok := tab.NoteAt(5, 4); ok {
+		t.Error("Expected the note to leave the low E string")
+	}
+	if note, ok := tab.NoteAt(4, 4); !ok || tab.MIDINote(4, note.Fret) != 40+25 {
+		t.Errorf("Expected the same pitch on the A string, got %+v", note)
+	}
+	if chord := tab.ChordAt(0); chord != "A/C#" {
+		t.Errorf("Expected chord A/C#, got %q", chord)
+	}
+}
+
+func TestTransposeHonorsTuning(t *testing.T) {
+	tab := NewEmptyTab("Drop D")
+	tab.Tuning[5] = "D"
+	tab.SetFret(5, 0, 24) // D4 on the low D string
+
+	tab.Transpose(tab.WholeTab(), 2)
+	if note, ok := tab.NoteAt(4, 0); !ok || note.Fret != 19 {
+		t.Errorf("Expected E4 at fret 19 on the A string, got %q", tab.Content[4])
+	}
+}
+
+func TestMoveToString(t *testing.T) {
+	tab := NewEmptyTab("Move")
+	tab.SetFret(1, 0, 5) // E4 on the B string
+
+	sel := Selection{FirstString: 1, LastString: 1, Start: 0, End: 0}
+	if failed := tab.MoveToString(sel, 1); failed != 0 {
+		t.Fatalf("Expected the note to move, %d failed", failed)
+	}
+	if note, ok := tab.NoteAt(2, 0); !ok || note.Fret != 9 {
+		t.Errorf("Expected fret 9 on the G string, got %q", tab.Content[2])
+	}
+
+	tab.SetFret(2, 4, 20) // D#5 is out of reach on the D string
+	sel = Selection{FirstString: 2, LastString: 2, Start: 4, End: 4}
+	if failed := tab.MoveToString(sel, 1); failed != 1 {
+		t.Errorf("Expected 1 note to fail, got %d", failed)
+	}
+	if note, ok := tab.NoteAt(2, 4); !ok || note.Fret != 20 {
+		t.Errorf("Expected the note to stay when it does not fit, got %q", tab.Content[2])
+	}
+}
+
+func TestRelocateCollision(t *testing.T) {
+	// Raised by 2, the 23 on the e string fits nowhere while the 23 on the
+	// B string would move into its cell
+	tab := NewEmptyTab("Collision")
+	tab.SetFret(0, 4, 23)
+	tab.SetFret(1, 4, 23)
+	before := tab.Content
+
+	if failed := tab.Transpose(tab.WholeTab(), 2); failed != 2 {
+		t.Errorf("Expected both notes to fail, got %d", failed)
+	}
+	if tab.Content != before {
+		t.Errorf("Expected the notes left as they were, got\n%s", strings.Join(tab.Content[:], "\n"))
+	}
+
+	// The e string note cannot go higher and the B string note could only
+	// take its place
+	tab = NewEmptyTab("Collision")
+	tab.SetFret(0, 4, 0)
+	tab.SetFret(1, 4, 5)
+	before = tab.Content
+	if failed := tab.MoveToString(Selection{FirstString: 0, LastString: 1, Start: 0, End: 15}, -1); failed != 2 {
+		t.Errorf("Expected both notes to fail, got %d", failed)
+	}
+	if tab.Content != before {
+		t.Errorf("Expected the notes left as they were, got\n%s", strings.Join(tab.Content[:], "\n"))
+	}
+
+	// Notes that can move still do around a note that stays
+	tab = NewEmptyTab("Collision")
+	tab.SetFret(0, 4, 23)
+	tab.SetFret(1, 4, 23)
+	tab.SetFret(5, 8, 3)
+	if failed := tab.Transpose(tab.WholeTab(), 2); failed != 2 {
+		t.Errorf("Expected 2 notes to fail, got %d", failed)
+	}
+	if note, ok := tab.NoteAt(5, 8); !ok || note.Fret != 5 {
+		t.Errorf("Expected fret 5 on the low E string, got %q", tab.Content[5])
+	}
+}
@@ -0,0 +1,94 @@
+// internal/models/tuning.go
+package models
+
+import (
+	"strings"
+)
+
+// TuningPresets maps the names of common tunings to their strings from the
+// lowest to the highest
+var TuningPresets = map[string]string{
+	"standard":  "E A D G B E",
+	"dropd":     "D A D G B E",
+	"dropc":     "C G C F A D",
+	"dadgad":    "D A D G A D",
+	"openg":     "D G D G B D",
+	"opend":     "D A D F# A D",
+	"opene":     "E B E G# B E",
+	"opena":     "E A E A C# E",
+	"halfdown":  "Eb Ab Db Gb Bb Eb",
+	"wholedown": "D G C F A D",
+}
+
+// ParseTuning reads a tuning written from the lowest string to the highest,
+// either as a preset name ("drop d"), as six space-separated note names
+// ("Eb Ab Db Gb Bb Eb") or run together ("DADGAD", "EbAbDbGbBbEb"). The
+// result is in tab order, index 0 being the highest string, which is
+// written in lower case when another string has its name, as in e B G D A E.
+func ParseTuning(text string) ([6]string, bool) {
+	var tuning [6]string
+
+	key := strings.ToLower(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(text))
+	if preset, ok := TuningPresets[key]; ok {
+		text = preset
+	}
+
+	names := strings.Fields(text)
+	if len(names) == 1 {
+		names = splitNoteNames(names[0])
+	}
+	if len(names) != 6 {
+		return tuning, false
+	}
+
+	for i, name := range names {
+		if _, ok := ParsePitchClass(name); !ok {
+			return tuning, false
+		}
+		tuning[5-i] = strings.ToUpper(name[:1]) + name[1:]
+	}
+	for _, name := range tuning[1:] {
+		if name == tuning[0] {
+			tuning[0] = strings.ToLower(name[:1]) + name[1:]
+			break
+		}
+	}
+	return tuning, true
+}
+
+// StringLabels returns the names of the strings from the highest, padded
+// to the longest so the staff lines after them start in one column
+func (t *Tab) StringLabels() [6]string {
+	width := 1
+	for _, name := range t.Tuning {
+		width = max(width, len(name))
+	}
+	var labels [6]string
+	for str, name := range t.Tuning {
+		labels[str] = name + strings.Repeat(" ", width-len(name))
+	}
+	return labels
+}
+
+// splitNoteNames splits run-together note names such as "DADGAD". Six
+// letters are six strings, so "EADGBE" keeps its B; otherwise a "#" or a
+// lower case "b" after a letter is an accidental.
+func splitNoteNames(text string) []string {
+	if len(text) == 6 && !strings.ContainsAny(text, "#") {
+		names := make([]string, 0, 6)
+		for _, r := range text {
+			names = append(names, string(r))
+		}
+		return names
+	}
+
+	var names []string
+	for i := 0; i < len(text); i++ {
+		if len(names) > 0 && (text[i] == '#' || text[i] == 'b') {
+			names[len(names)-1] += string(text[i])
+			continue
+		}
+		names = append(names, string(text[i]))
+	}
+	return names
+}
@@ -0,0 +1,43 @@
+package models
+
+import "testing"
+
+func TestParseTuning(t *testing.T) {
+	cases := map[string][6]string{
+		"DADGAD":            {"d", "A", "G", "D", "A", "D"},
+		"eadgbe":            {"e", "B", "G", "D", "A", "E"},
+		"Drop D":            {"E", "B", "G", "D", "A", "D"},
+		"open-g":            {"d", "B", "G", "D", "G", "D"},
+		"Eb Ab Db Gb Bb Eb": {"eb", "Bb", "Gb", "Db", "Ab", "Eb"},
+		"EbAbDbGbBbEb":      {"eb", "Bb", "Gb", "Db", "Ab", "Eb"},
+		"D A D F# A D":      {"d", "A", "F#", "D", "A", "D"},
+	}
+	for text, want := range cases {
+		got, ok := ParseTuning(text)
+		if !ok {
+			t.Errorf("ParseTuning(%q) failed", text)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseTuning(%q) = %v, want %v", text, got, want)
+		}
+	}
+
+	for _, text := range []string{"", "DADGA", "E A D G B H", "DADGADE", "sideways"} {
+		if _, ok := ParseTuning(text); ok {
+			t.Errorf("Expected ParseTuning(%q) to fail", text)
+		}
+	}
+}
+
+func TestStringLabels(t *testing.T) {
+	tab := NewEmptyTab("Labels")
+	if got := tab.StringLabels(); got != [6]string{"e", "B", "G", "D", "A", "E"} {
+		t.Errorf("Expected standard labels, got %v", got)
+	}
+
+	tab.Tuning, _ = ParseTuning("D A D F# A D")
+	if got := tab.StringLabels(); got != [6]string{"d ", "A ", "F#", "D ", "A ", "D "} {
+		t.Errorf("Expected labels padded to F#, got %q", got)
+	}
+}
@@ -0,0 +1,327 @@
+// internal/render/svg.go
+package render
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// Layout constants in SVG user units (pixels)
+const (
+	columnWidth   = 11 // Horizontal space for one tab column
+	stringSpacing = 12 // Vertical space between strings
+	measurePad    = 6  // Space between a bar line and the first/last column
+	labelWidth    = 24 // Space for the tuning labels left of each system
+	margin        = 20
+	headerHeight  = 64 // Title and artist block
+	systemTop     = 30 // Space above the top string for measure numbers, voltas and markings
+	systemGap     = 28 // Space between systems
+	fontSize      = 10
+)
+
+// Options controls the page layout of rendered tabs
+type Options struct {
+	Width      int // Page width; measures wrap onto new systems to fit
+	PageHeight int // Page height for HTML output; 0 puts everything on one page
+}
+
+// DefaultOptions returns a layout that fits an A4/Letter page at 96 DPI
+func DefaultOptions() Options {
+	return Options{Width: 760, PageHeight: 1040}
+}
+
+// layout holds the computed geometry of a rendering
+type layout struct {
+	tab             *models.Tab
+	width           int
+	measuresPerLine int
+	measureWidth    int
+	systemHeight    int
+}
+
+func newLayout(tab *models.Tab, opts Options) layout {
+	if opts.Width <= 0 {
+		opts.Width = DefaultOptions().Width
+	}
+
+	l := layout{
+		tab:          tab,
+		width:        opts.Width,
+		measureWidth: models.MeasureLength*columnWidth + 2*measurePad,
+		systemHeight: systemTop + 5*stringSpacing + systemGap,
+	}
+
+	available := opts.Width - 2*margin - labelWidth
+	l.measuresPerLine = available / l.measureWidth
+	if l.measuresPerLine < 1 {
+		l.measuresPerLine = 1
+	}
+	return l
+}
+
+// systems returns the number of staff lines needed for the whole tab
+func (l layout) systems() int {
+	measures := l.tab.GetMeasureCount()
+	if measures == 0 {
+		return 0
+	}
+	return (measures + l.measuresPerLine - 1) / l.measuresPerLine
+}
+
+// writeHeader draws the title and artist centered at the top of the page
+func (l layout) writeHeader(b *strings.Builder) {
+	center := l.width / 2
+	fmt.Fprintf(b, `<text x="%d" y="%d" font-size="22" font-weight="bold" text-anchor="middle">%s</text>`+"\n",
+		center, margin+22, html.EscapeString(l.tab.Name))
+	if l.tab.Artist != "" {
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="13" text-anchor="middle">%s</text>`+"\n",
+			center, margin+42, html.EscapeString(l.tab.Artist))
+	}
+	fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d">%s</text>`+"\n",
+		margin, headerHeight+margin-8, fontSize, html.EscapeString(tabInfo(l.tab)))
+}
+
+// tabInfo summarizes tempo, time signature and tuning for the header
+func tabInfo(tab *models.Tab) string {
+	tuning := make([]string, 0, 6)
+	for i := 5; i >= 0; i-- {
+		tuning = append(tuning, tab.Tuning[i])
+	}
+	tempo := tab.Tempo
+	if tempo <= 0 {
+		tempo = 120
+	}
+	info := fmt.Sprintf("♩ = %d   %s   Tuning: %s", tempo, tab.TimeSignature, strings.Join(tuning, " "))
+	if tab.Capo > 0 {
+		info += fmt.Sprintf("   Capo %d", tab.Capo)
+	}
+	return info
+}
+
+// writeSystem draws one staff line of measures with its top string at y
+func (l layout) writeSystem(b *strings.Builder, system, y int) {
+	firstMeasure := system * l.measuresPerLine
+	lastMeasure := firstMeasure + l.measuresPerLine
+	if lastMeasure > l.tab.GetMeasureCount() {
+		lastMeasure = l.tab.GetMeasureCount()
+	}
+
+	left := margin + labelWidth
+	right := left + (lastMeasure-firstMeasure)*l.measureWidth
+	bottom := y + 5*stringSpacing
+
+	// Tuning labels and string lines
+	for str := 0; str < 6; str++ {
+		lineY := y + str*stringSpacing
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d" text-anchor="end">%s</text>`+"\n",
+			left-8, lineY+fontSize/2-1, fontSize, html.EscapeString(l.tab.Tuning[str]))
+		fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="string"/>`+"\n", left, lineY, right, lineY)
+	}
+
+	// Opening bar line
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="bar"/>`+"\n", left, y, left, bottom)
+
+	notes := l.tab.Notes()
+	for measure := firstMeasure; measure < lastMeasure; measure++ {
+		measureX := left + (measure-firstMeasure)*l.measureWidth
+		measureEnd := measureX + l.measureWidth
+
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d" class="measure-number">%d</text>`+"\n",
+			measureX+2, y-6, fontSize-1, measure+1)
+		l.writeForm(b, measure, measureX, y)
+
+		start := measure * models.MeasureLength
+		for _, note := range notes {
+			if note.Position < start || note.Position >= start+models.MeasureLength {
+				continue
+			}
+			column := float64(note.Position-start) + float64(note.Width-1)/2
+			x := float64(measureX+measurePad) + column*columnWidth + columnWidth/2.0
+			noteY := y + note.String*stringSpacing
+			textWidth := note.Width * 7
+			fmt.Fprintf(b, `<rect x="%.1f" y="%d" width="%d" height="%d" class="knockout"/>`+"\n",
+				x-float64(textWidth)/2, noteY-fontSize/2, textWidth, fontSize)
+			fmt.Fprintf(b, `<text x="%.1f" y="%d" font-size="%d" text-anchor="middle" class="fret">%d</text>`+"\n",
+				x, noteY+fontSize/2-1, fontSize, note.Fret)
+		}
+
+		// A repeat closes the measure like the end of the tab, with dots
+		barClass := "bar"
+		repeatEnd := l.tab.FormAt(measure).RepeatEnd > 0
+		if measure == l.tab.GetMeasureCount()-1 || repeatEnd {
+			barClass = "bar final"
+			fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="bar"/>`+"\n", measureEnd-4, y, measureEnd-4, bottom)
+		}
+		if repeatEnd {
+			writeRepeatDots(b, measureEnd-8, y)
+		}
+		fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="%s"/>`+"\n", measureEnd, y, measureEnd, bottom, barClass)
+	}
+}
+
+// writeForm draws the repeat and navigation markings of a measure whose
+// top string is at y: a forward repeat sign, the volta bracket over an
+// ending, and above it segno and coda signs at the start and To Coda,
+// Fine, jumps and the number of plays at the end, as the editor shows them
+func (l layout) writeForm(b *strings.Builder, measure, measureX, y int) {
+	form := l.tab.FormAt(measure)
+	measureEnd := measureX + l.measureWidth
+	bottom := y + 5*stringSpacing
+
+	if form.RepeatStart {
+		fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="bar final"/>`+"\n", measureX+2, y, measureX+2, bottom)
+		fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="bar"/>`+"\n", measureX+6, y, measureX+6, bottom)
+		writeRepeatDots(b, measureX+10, y)
+	}
+
+	if form.Ending > 0 {
+		// The bracket opens with a hook and its number where the ending
+		// starts, and closes with a hook before a later ending
+		voltaY := y - systemTop + 11
+		fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="volta"/>`+"\n", measureX+2, voltaY, measureEnd-2, voltaY)
+		if measure == 0 || l.tab.FormAt(measure-1).Ending != form.Ending {
+			fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="volta"/>`+"\n", measureX+2, voltaY, measureX+2, voltaY+6)
+			fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d">%d.</text>`+"\n", measureX+18, voltaY+8, fontSize-1, form.Ending)
+		}
+		if next := l.tab.FormAt(measure + 1); next.Ending > 0 && next.Ending != form.Ending {
+			fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="volta"/>`+"\n", measureEnd-2, voltaY, measureEnd-2, voltaY+6)
+		}
+	}
+
+	var left, right []string
+	if form.Segno {
+		left = append(left, "§")
+	}
+	if form.Coda {
+		left = append(left, "⊕")
+	}
+	if form.ToCoda {
+		right = append(right, "To ⊕")
+	}
+	if form.Fine {
+		right = append(right, "Fine")
+	}
+	if form.Jump != models.JumpNone {
+		right = append(right, string(form.Jump))
+	}
+	if form.RepeatEnd > 2 {
+		right = append(right, fmt.Sprintf("×%d", form.RepeatEnd))
+	}
+
+	textY := y - systemTop + 8
+	if len(left) > 0 {
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d" class="form">%s</text>`+"\n",
+			measureX+2, textY, fontSize+1, html.EscapeString(strings.Join(left, " ")))
+	}
+	if len(right) > 0 {
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d" text-anchor="end" class="form">%s</text>`+"\n",
+			measureEnd-2, textY, fontSize+1, html.EscapeString(strings.Join(right, " ")))
+	}
+}
+
+// writeRepeatDots draws the two dots of a repeat sign at x, in the
+// spaces above and below the middle of the staff
+func writeRepeatDots(b *strings.Builder, x, y int) {
+	for _, space := range []int{1, 3} {
+		fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="1.8" class="repeat-dot"/>`+"\n", x, y+space*stringSpacing+stringSpacing/2)
+	}
+}
+
+// svgStyle holds the shared presentation rules for rendered tabs
+const svgStyle = `<style>
+  text { font-family: "DejaVu Sans Mono", Menlo, Consolas, monospace; fill: #000; }
+  .string { stroke: #555; stroke-width: 0.8; }
+  .bar { stroke: #000; stroke-width: 1; }
+  .bar.final { stroke-width: 3; }
+  .knockout { fill: #fff; }
+  .measure-number { fill: #777; font-style: italic; }
+  .repeat-dot { fill: #000; }
+  .volta { stroke: #000; stroke-width: 1; }
+  .form { font-weight: bold; font-style: italic; }
+</style>
+`
+
+// writeSVG renders the given range of systems as a standalone SVG element
+func (l layout) writeSVG(b *strings.Builder, firstSystem, lastSystem int, header bool) {
+	top := margin
+	if header {
+		top += headerHeight
+	}
+	height := top + (lastSystem-firstSystem)*l.systemHeight + margin
+
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		l.width, height, l.width, height)
+	b.WriteString(svgStyle)
+	fmt.Fprintf(b, `<rect x="0" y="0" width="%d" height="%d" fill="#fff"/>`+"\n", l.width, height)
+	if header {
+		l.writeHeader(b)
+	}
+	for system := firstSystem; system < lastSystem; system++ {
+		l.writeSystem(b, system, top+systemTop+(system-firstSystem)*l.systemHeight)
+	}
+	b.WriteString("</svg>\n")
+}
+
+// WriteSVG renders the whole tab as a single standalone SVG image
+func WriteSVG(w io.Writer, tab *models.Tab, opts Options) error {
+	l := newLayout(tab, opts)
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	l.writeSVG(&b, 0, l.systems(), true)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteHTML renders the tab as a self-contained HTML page, split into
+// printable pages of inline SVG
+func WriteHTML(w io.Writer, tab *models.Tab, opts Options) error {
+	l := newLayout(tab, opts)
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(tab.Name))
+	b.WriteString("<style>\n")
+	b.WriteString("  body { margin: 0; background: #eee; }\n")
+	b.WriteString("  .page { background: #fff; margin: 16px auto; width: fit-content; box-shadow: 0 1px 4px #999; }\n")
+	b.WriteString("  .page svg { display: block; }\n")
+	b.WriteString("  @media print { body { background: #fff; } .page { margin: 0; box-shadow: none; page-break-after: always; } }\n")
+	b.WriteString("</style>\n</head>\n<body>\n")
+
+	total := l.systems()
+	first := 0
+	for page := 0; first < total || page == 0; page++ {
+		header := page == 0
+		perPage := total
+		if opts.PageHeight > 0 {
+			usable := opts.PageHeight - 2*margin
+			if header {
+				usable -= headerHeight
+			}
+			perPage = usable / l.systemHeight
+			if perPage < 1 {
+				perPage = 1
+			}
+		}
+
+		last := first + perPage
+		if last > total {
+			last = total
+		}
+
+		b.WriteString("<div class=\"page\">\n")
+		l.writeSVG(&b, first, last, header)
+		b.WriteString("</div>\n")
+		first = last
+	}
+
+	b.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,87 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+func TestWriteSVG(t *testing.T) {
+	tab := models.NewTestTab("Wiki <Tab>")
+	tab.Artist = "Band & Co"
+
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, tab, Options{Width: 500}); err != nil {
+		t.Fatalf("WriteSVG failed: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "Wiki &lt;Tab&gt;") || !strings.Contains(out, "Band &amp; Co") {
+		t.Error("Expected escaped title and artist in header")
+	}
+
+	// 7 test notes, one fret label each
+	if got := strings.Count(out, `class="fret"`); got != 7 {
+		t.Errorf("Expected 7 fret labels, got %d", got)
+	}
+
+	// 500px fits two measures per system, so 4 measures need 2 systems of 6 strings
+	if got := strings.Count(out, `class="string"`); got != 12 {
+		t.Errorf("Expected 12 string lines, got %d", got)
+	}
+}
+
+func TestWriteHTMLPaginates(t *testing.T) {
+	tab := models.NewEmptyTab("Long Song")
+	for i := 0; i < 60; i++ {
+		tab.AddMeasure()
+	}
+
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, tab, DefaultOptions()); err != nil {
+		t.Fatalf("WriteHTML failed: %v", err)
+	}
+
+	if pages := strings.Count(buf.String(), `<div class="page">`); pages < 2 {
+		t.Errorf("Expected multiple pages, got %d", pages)
+	}
+}
+
+func TestWriteSVGForm(t *testing.T) {
+	tab := models.NewTestTab("Form")
+	tab.SetForm(models.MeasureForm{Measure: 0, RepeatStart: true, Segno: true})
+	tab.SetForm(models.MeasureForm{Measure: 1, Ending: 1, RepeatEnd: 3})
+	tab.SetForm(models.MeasureForm{Measure: 2, Ending: 2, ToCoda: true})
+	tab.SetForm(models.MeasureForm{Measure: 3, Coda: true, Jump: models.JumpDSalCoda})
+
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, tab, Options{Width: 500}); err != nil {
+		t.Fatalf("WriteSVG failed: %v", err)
+	}
+	out := buf.String()
+
+	// Dots for the repeat start and end
+	if got := strings.Count(out, `class="repeat-dot"`); got != 4 {
+		t.Errorf("Expected 4 repeat dots, got %d", got)
+	}
+	// Two brackets, each opened by a hook; the first closed by another
+	if got := strings.Count(out, `class="volta"`); got != 5 {
+		t.Errorf("Expected 5 volta lines, got %d", got)
+	}
+	for _, want := range []string{">1.</text>", ">2.</text>", ">§</text>", ">⊕</text>", "To ⊕", "×3", "D.S. al Coda"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in the output", want)
+		}
+	}
+
+	// Without markings nothing is added
+	buf.Reset()
+	if err := WriteSVG(&buf, models.NewTestTab("Plain"), Options{Width: 500}); err != nil {
+		t.Fatalf("WriteSVG failed: %v", err)
+	}
+	if strings.Contains(buf.String(), `class="volta"`) || strings.Contains(buf.String(), `class="form"`) {
+		t.Error("Expected no form markings for a tab without them")
+	}
+}
@@ -38,9 +38,15 @@ func (s *SQLiteStorage) migrate() error {
 		artist TEXT DEFAULT '',
 		content TEXT NOT NULL,
 		tuning TEXT NOT NULL,
+		capo INTEGER DEFAULT 0,
 		tempo INTEGER DEFAULT 120,
 		time_signature TEXT DEFAULT '4/4',
 		measures INTEGER DEFAULT 4,
+		chords TEXT DEFAULT '[]',
+		lyrics TEXT DEFAULT '[]',
+		sections TEXT DEFAULT '[]',
+		form TEXT DEFAULT '[]',
+		marks TEXT DEFAULT '[]',
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 	);
@@ -54,25 +60,89 @@ func (s *SQLiteStorage) migrate() error {
 		return err
 	}
 
-	// Add measures column if it doesn't exist (for existing databases)
-	alterQuery := `ALTER TABLE tabs ADD COLUMN measures INTEGER DEFAULT 4;`
-	_, _ = s.db.Exec(alterQuery) // Ignore error if column already exists
+	// Add columns introduced after the first release (for existing databases)
+	alterQueries := []string{
+		`ALTER TABLE tabs ADD COLUMN measures INTEGER DEFAULT 4;`,
+		`ALTER TABLE tabs ADD COLUMN chords TEXT DEFAULT '[]';`,
+		`ALTER TABLE tabs ADD COLUMN lyrics TEXT DEFAULT '[]';`,
+		`ALTER TABLE tabs ADD COLUMN sections TEXT DEFAULT '[]';`,
+		`ALTER TABLE tabs ADD COLUMN form TEXT DEFAULT '[]';`,
+		`ALTER TABLE tabs ADD COLUMN capo INTEGER DEFAULT 0;`,
+		`ALTER TABLE tabs ADD COLUMN marks TEXT DEFAULT '[]';`,
+	}
+	for _, alterQuery := range alterQueries {
+		_, _ = s.db.Exec(alterQuery) // Ignore error if column already exists
+	}
 
 	return nil
 }
 
+// tabColumns lists the columns read by scanTab, in order
+const tabColumns = `id, name, artist, content, tuning, capo, tempo, time_signature, measures, chords, lyrics, sections, form, marks, created_at, updated_at`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTab reads a tab selected with tabColumns
+func scanTab(row rowScanner) (*models.Tab, error) {
+	var tab models.Tab
+	var contentJSON, tuningJSON string
+	var chordsJSON, lyricsJSON, sectionsJSON, formJSON, marksJSON sql.NullString
+
+	err := row.Scan(&tab.ID, &tab.Name, &tab.Artist, &contentJSON, &tuningJSON, &tab.Capo,
+		&tab.Tempo, &tab.TimeSignature, &tab.Measures, &chordsJSON, &lyricsJSON,
+		&sectionsJSON, &formJSON, &marksJSON, &tab.CreatedAt, &tab.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = json.Unmarshal([]byte(contentJSON), &tab.Content)
+	_ = json.Unmarshal([]byte(tuningJSON), &tab.Tuning)
+	if chordsJSON.Valid {
+		_ = json.Unmarshal([]byte(chordsJSON.String), &tab.Chords)
+	}
+	if lyricsJSON.Valid {
+		_ = json.Unmarshal([]byte(lyricsJSON.String), &tab.Lyrics)
+	}
+	if sectionsJSON.Valid {
+		_ = json.Unmarshal([]byte(sectionsJSON.String), &tab.Sections)
+	}
+	if formJSON.Valid {
+		_ = json.Unmarshal([]byte(formJSON.String), &tab.Form)
+	}
+	if marksJSON.Valid {
+		_ = json.Unmarshal([]byte(marksJSON.String), &tab.Marks)
+	}
+
+	// Set default measures if not set
+	if tab.Measures == 0 {
+		tab.Measures = 4
+	}
+
+	return &tab, nil
+}
+
 func (s *SQLiteStorage) SaveTab(tab *models.Tab) error {
 	contentJSON, _ := json.Marshal(tab.Content)
 	tuningJSON, _ := json.Marshal(tab.Tuning)
+	chordsJSON, _ := json.Marshal(emptyIfNil(tab.Chords))
+	lyricsJSON, _ := json.Marshal(emptyIfNil(tab.Lyrics))
+	sectionsJSON, _ := json.Marshal(emptyIfNil(tab.Sections))
+	formJSON, _ := json.Marshal(emptyIfNil(tab.Form))
+	marksJSON, _ := json.Marshal(emptyIfNil(tab.Marks))
 
 	if tab.ID == 0 {
 		// Insert new tab
 		query := `
-			INSERT INTO tabs (name, artist, content, tuning, tempo, time_signature, measures, created_at, updated_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			INSERT INTO tabs (name, artist, content, tuning, capo, tempo, time_signature, measures, chords, lyrics,
+			sections, form, marks, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`
-		result, err := s.db.Exec(query, tab.Name, tab.Artist, contentJSON, tuningJSON,
-			tab.Tempo, tab.TimeSignature, tab.Measures, tab.CreatedAt, time.Now())
+		result, err := s.db.Exec(query, tab.Name, tab.Artist, contentJSON, tuningJSON, tab.Capo,
+			tab.Tempo, tab.TimeSignature, tab.Measures, chordsJSON, lyricsJSON,
+			sectionsJSON, formJSON, marksJSON, tab.CreatedAt, time.Now())
 		if err != nil {
 			return err
 		}
@@ -82,11 +152,13 @@ func (s *SQLiteStorage) SaveTab(tab *models.Tab) error {
 	} else {
 		// Update existing tab
 		query := `
-			UPDATE tabs SET name=?, artist=?, content=?, tuning=?, tempo=?, 
-			time_signature=?, measures=?, updated_at=? WHERE id=?
+			UPDATE tabs SET name=?, artist=?, content=?, tuning=?, capo=?, tempo=?, 
+			time_signature=?, measures=?, chords=?, lyrics=?, sections=?, form=?, marks=?,
+			updated_at=? WHERE id=?
 		`
-		_, err := s.db.Exec(query, tab.Name, tab.Artist, contentJSON, tuningJSON,
-			tab.Tempo, tab.TimeSignature, tab.Measures, time.Now(), tab.ID)
+		_, err := s.db.Exec(query, tab.Name, tab.Artist, contentJSON, tuningJSON, tab.Capo,
+			tab.Tempo, tab.TimeSignature, tab.Measures, chordsJSON, lyricsJSON,
+			sectionsJSON, formJSON, marksJSON, time.Now(), tab.ID)
 		if err != nil {
 			return err
 		}
@@ -98,33 +170,13 @@ func (s *SQLiteStorage) SaveTab(tab *models.Tab) error {
 
 func (s *SQLiteStorage) LoadTab(id int) (*models.Tab, error) {
 	// Use explicit column order to match our struct
-	query := `SELECT id, name, artist, content, tuning, tempo, time_signature, measures, created_at, updated_at FROM tabs WHERE id = ?`
-	row := s.db.QueryRow(query, id)
-
-	var tab models.Tab
-	var contentJSON, tuningJSON string
-
-	err := row.Scan(&tab.ID, &tab.Name, &tab.Artist, &contentJSON, &tuningJSON,
-		&tab.Tempo, &tab.TimeSignature, &tab.Measures, &tab.CreatedAt, &tab.UpdatedAt)
-	if err != nil {
-		return nil, err
-	}
-
-	_ = json.Unmarshal([]byte(contentJSON), &tab.Content)
-	_ = json.Unmarshal([]byte(tuningJSON), &tab.Tuning)
-
-	// Set default measures if not set
-	if tab.Measures == 0 {
-		tab.Measures = 4
-	}
-
-	return &tab, nil
+	query := `SELECT ` + tabColumns + ` FROM tabs WHERE id = ?`
+	return scanTab(s.db.QueryRow(query, id))
 }
 
 func (s *SQLiteStorage) LoadAllTabs() ([]models.Tab, error) {
 	// Use explicit column order to match our struct
-	query := `SELECT id, name, artist, content, tuning, tempo, time_signature, measures, 
-		created_at, updated_at FROM tabs ORDER BY updated_at DESC`
+	query := `SELECT ` + tabColumns + ` FROM tabs ORDER BY updated_at DESC`
 	rows, err := s.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -133,24 +185,12 @@ func (s *SQLiteStorage) LoadAllTabs() ([]models.Tab, error) {
 
 	var tabs []models.Tab
 	for rows.Next() {
-		var tab models.Tab
-		var contentJSON, tuningJSON string
-
-		err := rows.Scan(&tab.ID, &tab.Name, &tab.Artist, &contentJSON, &tuningJSON,
-			&tab.Tempo, &tab.TimeSignature, &tab.Measures, &tab.CreatedAt, &tab.UpdatedAt)
+		tab, err := scanTab(rows)
 		if err != nil {
 			continue
 		}
 
-		_ = json.Unmarshal([]byte(contentJSON), &tab.Content)
-		_ = json.Unmarshal([]byte(tuningJSON), &tab.Tuning)
-
-		// Set default measures if not set
-		if tab.Measures == 0 {
-			tab.Measures = 4
-		}
-
-		tabs = append(tabs, tab)
+		tabs = append(tabs, *tab)
 	}
 
 	return tabs, nil
@@ -165,7 +205,7 @@ func (s *SQLiteStorage) DeleteTab(id int) error {
 func (s *SQLiteStorage) SearchTabs(query string) ([]models.Tab, error) {
 	// Use explicit column order to match our struct
 	sqlQuery := `
-		SELECT id, name, artist, content, tuning, tempo, time_signature, measures, created_at, updated_at FROM tabs 
+		SELECT ` + tabColumns + ` FROM tabs 
 		WHERE name LIKE ? OR artist LIKE ? 
 		ORDER BY updated_at DESC
 	`
@@ -179,29 +219,25 @@ func (s *SQLiteStorage) SearchTabs(query string) ([]models.Tab, error) {
 
 	var tabs []models.Tab
 	for rows.Next() {
-		var tab models.Tab
-		var contentJSON, tuningJSON string
-
-		err := rows.Scan(&tab.ID, &tab.Name, &tab.Artist, &contentJSON, &tuningJSON,
-			&tab.Tempo, &tab.TimeSignature, &tab.Measures, &tab.CreatedAt, &tab.UpdatedAt)
+		tab, err := scanTab(rows)
 		if err != nil {
 			continue
 		}
 
-		_ = json.Unmarshal([]byte(contentJSON), &tab.Content)
-		_ = json.Unmarshal([]byte(tuningJSON), &tab.Tuning)
-
-		// Set default measures if not set
-		if tab.Measures == 0 {
-			tab.Measures = 4
-		}
-
-		tabs = append(tabs, tab)
+		tabs = append(tabs, *tab)
 	}
 
 	return tabs, nil
 }
 
+// emptyIfNil stores nil slices as an empty JSON array rather than null
+func emptyIfNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
 func (s *SQLiteStorage) Close() error {
 	return s.db.Close()
 }
@@ -0,0 +1,251 @@
+// internal/theory/chords.go
+package theory
+
+import (
+	"sort"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// Quality is a chord type described by its intervals above the root
+type Quality struct {
+	Name      string // Long name shown in the picker, e.g. "minor 7th"
+	Suffix    string // Symbol suffix, e.g. "m7"
+	Intervals []int  // Semitones above the root, starting with 0 and stacked in thirds
+	Optional  []int  // Intervals that may be left out of a voicing
+}
+
+// Qualities lists the chord types of the library in picker order
+var Qualities = []Quality{
+	{Name: "major", Suffix: "", Intervals: []int{0, 4, 7}},
+	{Name: "minor", Suffix: "m", Intervals: []int{0, 3, 7}},
+	{Name: "dominant 7th", Suffix: "7", Intervals: []int{0, 4, 7, 10}, Optional: []int{7}},
+	{Name: "major 7th", Suffix: "maj7", Intervals: []int{0, 4, 7, 11}, Optional: []int{7}},
+	{Name: "minor 7th", Suffix: "m7", Intervals: []int{0, 3, 7, 10}, Optional: []int{7}},
+	{Name: "6th", Suffix: "6", Intervals: []int{0, 4, 7, 9}, Optional: []int{7}},
+	{Name: "minor 6th", Suffix: "m6", Intervals: []int{0, 3, 7, 9}, Optional: []int{7}},
+	{Name: "suspended 2nd", Suffix: "sus2", Intervals: []int{0, 2, 7}},
+	{Name: "suspended 4th", Suffix: "sus4", Intervals: []int{0, 5, 7}},
+	{Name: "7th suspended 4th", Suffix: "7sus4", Intervals: []int{0, 5, 7, 10}, Optional: []int{7}},
+	{Name: "add 9", Suffix: "add9", Intervals: []int{0, 4, 7, 2}, Optional: []int{7}},
+	{Name: "dominant 9th", Suffix: "9", Intervals: []int{0, 4, 7, 10, 2}, Optional: []int{7}},
+	{Name: "diminished", Suffix: "dim", Intervals: []int{0, 3, 6}},
+	{Name: "diminished 7th", Suffix: "dim7", Intervals: []int{0, 3, 6, 9}},
+	{Name: "half-diminished", Suffix: "m7b5", Intervals: []int{0, 3, 6, 10}},
+	{Name: "augmented", Suffix: "aug", Intervals: []int{0, 4, 8}},
+	{Name: "power chord", Suffix: "5", Intervals: []int{0, 7}},
+}
+
+// Chord is a root pitch class with a quality
+type Chord struct {
+	Root    int // Pitch class, 0 = C
+	Quality Quality
+}
+
+// Name returns the chord symbol, e.g. "F#m7"
+func (c Chord) Name() string {
+	return models.NoteNames[c.Root%12] + c.Quality.Suffix
+}
+
+// pitchClasses returns the pitch classes of the chord tones mapped to
+// their interval above the root
+func (c Chord) pitchClasses() map[int]int {
+	classes := make(map[int]int, len(c.Quality.Intervals))
+	for _, interval := range c.Quality.Intervals {
+		classes[(c.Root+interval)%12] = interval
+	}
+	return classes
+}
+
+// Muted marks a string that is not played in a voicing
+const Muted = -1
+
+// Voicing is a chord shape: the fret played on each string, index 0 being
+// the highest string, or Muted
+type Voicing struct {
+	Frets [6]int
+}
+
+// BaseFret returns the lowest fretted (non-open) fret of the shape, or 0
+// for shapes using only open strings
+func (v Voicing) BaseFret() int {
+	base := 0
+	for _, fret := range v.Frets {
+		if fret > 0 && (base == 0 || fret < base) {
+			base = fret
+		}
+	}
+	return base
+}
+
+// Voicing search limits
+const (
+	maxSpan      = 3  // Highest minus lowest fretted fret
+	maxFingers   = 4  // Fretting fingers, counting a barre as one
+	maxPosition  = 12 // Highest base fret searched
+	minStrings   = 3  // Strings sounding in a voicing (power chords need 2)
+	maxVoicings  = 12 // Voicings returned per chord
+	rootBassCost = 20 // Penalty for a voicing whose lowest note is not the root
+)
+
+// Voicings generates playable shapes for the chord on an instrument whose
+// strings sound the given MIDI notes at fret 0 (index 0 = highest string).
+// Shapes are ordered from the most common kind (low position, root in the
+// bass, no gaps) to the least.
+func Voicings(chord Chord, tuning [6]int) []Voicing {
+	classes := chord.pitchClasses()
+	required := make(map[int]bool)
+	for _, interval := range chord.Quality.Intervals {
+		required[interval] = true
+	}
+	for _, interval := range chord.Quality.Optional {
+		delete(required, interval)
+	}
+
+	minSounding := minStrings
+	if len(chord.Quality.Intervals) < minStrings {
+		minSounding = len(chord.Quality.Intervals)
+	}
+
+	type scored struct {
+		voicing Voicing
+		cost    int
+	}
+	seen := make(map[[6]int]bool)
+	var found []scored
+
+	for position := 1; position <= maxPosition; position++ {
+		// Choices per string: muted, open, or a fret in the window
+		var choices [6][]int
+		for str := 0; str < 6; str++ {
+			choices[str] = []int{Muted}
+			if _, ok := classes[tuning[str]%12]; ok {
+				choices[str] = append(choices[str], 0)
+			}
+			for fret := position; fret <= position+maxSpan; fret++ {
+				if _, ok := classes[(tuning[str]+fret)%12]; ok {
+					choices[str] = append(choices[str], fret)
+				}
+			}
+		}
+
+		var frets [6]int
+		var walk func(str int)
+		walk = func(str int) {
+			if str < 6 {
+				for _, fret := range choices[str] {
+					frets[str] = fret
+					walk(str + 1)
+				}
+				return
+			}
+			if seen[frets] {
+				return
+			}
+			if cost, ok := voicingCost(frets, tuning, chord.Root, classes, required, minSounding); ok {
+				seen[frets] = true
+				found = append(found, scored{Voicing{frets}, cost})
+			}
+		}
+		walk(0)
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].cost != found[j].cost {
+			return found[i].cost < found[j].cost
+		}
+		return found[i].voicing.BaseFret() < found[j].voicing.BaseFret()
+	})
+
+	var voicings []Voicing
+	for _, f := range found {
+		if len(voicings) == maxVoicings {
+			break
+		}
+		voicings = append(voicings, f.voicing)
+	}
+	return voicings
+}
+
+// voicingCost checks that a shape is playable and contains the chord, and
+// rates how natural it is; lower is better
+func voicingCost(frets [6]int, tuning [6]int, root int, classes map[int]int, required map[int]bool, minSounding int) (int, bool) {
+	sounding := 0
+	lowest := -1 // Lowest sounding string (highest index)
+	highest := -1
+	minFret, maxFret := 0, 0
+	fretted := 0
+	present := make(map[int]bool)
+
+	for str := 0; str < 6; str++ {
+		fret := frets[str]
+		if fret == Muted {
+			continue
+		}
+		sounding++
+		lowest = str
+		if highest == -1 {
+			highest = str
+		}
+		present[classes[(tuning[str]+fret)%12]] = true
+		if fret > 0 {
+			fretted++
+			if minFret == 0 || fret < minFret {
+				minFret = fret
+			}
+			if fret > maxFret {
+				maxFret = fret
+			}
+		}
+	}
+
+	if sounding < minSounding {
+		return 0, false
+	}
+	for interval := range required {
+		if !present[interval] {
+			return 0, false
+		}
+	}
+	if fretted > 0 && maxFret-minFret > maxSpan {
+		return 0, false
+	}
+
+	// Muted strings must be at the edges; strumming cannot skip a string
+	for str := highest; str <= lowest; str++ {
+		if frets[str] == Muted {
+			return 0, false
+		}
+	}
+
+	// A barre at the lowest fret frees fingers, but it stops every string
+	// above the lowest barred one, so none of those may ring open
+	fingers := fretted
+	barred, barreLow := 0, -1
+	for str, fret := range frets {
+		if fret > 0 && fret == minFret {
+			barred++
+			barreLow = str
+		}
+	}
+	if barred > 1 {
+		barre := true
+		for str := highest; str < barreLow; str++ {
+			if frets[str] == 0 {
+				barre = false
+			}
+		}
+		if barre {
+			fingers = fretted - barred + 1
+		}
+	}
+	if fingers > maxFingers {
+		return 0, false
+	}
+
+	cost := minFret*2 + (maxFret - minFret) + (6-sounding)*2 + fingers
+	if (tuning[lowest]+frets[lowest])%12 != root {
+		cost += rootBassCost
+	}
+	return cost, true
+}
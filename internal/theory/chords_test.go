@@ -0,0 +1,77 @@
+package theory
+
+import (
+	"testing"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// chordQuality looks up a quality of the library by its suffix
+func chordQuality(suffix string) Quality {
+	for _, quality := range Qualities {
+		if quality.Suffix == suffix {
+			return quality
+		}
+	}
+	panic("unknown chord quality " + suffix)
+}
+
+// hasVoicing reports whether the frets, written low string first like a
+// chord chart, are among the voicings
+func hasVoicing(voicings []Voicing, chart [6]int) bool {
+	for _, v := range voicings {
+		match := true
+		for str := 0; str < 6; str++ {
+			if v.Frets[str] != chart[5-str] {
+				match = false
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func TestVoicingsStandardTuning(t *testing.T) {
+	tests := []struct {
+		chord Chord
+		chart [6]int
+	}{
+		{Chord{Root: 4, Quality: chordQuality("")}, [6]int{0, 2, 2, 1, 0, 0}},             // Open E
+		{Chord{Root: 9, Quality: chordQuality("m")}, [6]int{Muted, 0, 2, 2, 1, 0}},        // Open Am
+		{Chord{Root: 0, Quality: chordQuality("")}, [6]int{Muted, 3, 2, 0, 1, 0}},         // Open C
+		{Chord{Root: 5, Quality: chordQuality("")}, [6]int{1, 3, 3, 2, 1, 1}},             // F barre
+		{Chord{Root: 7, Quality: chordQuality("7")}, [6]int{3, 2, 0, 0, 0, 1}},            // Open G7
+		{Chord{Root: 2, Quality: chordQuality("sus4")}, [6]int{Muted, Muted, 0, 2, 3, 3}}, // Open Dsus4
+	}
+
+	for _, tt := range tests {
+		voicings := Voicings(tt.chord, models.StandardTuningMIDI)
+		if !hasVoicing(voicings, tt.chart) {
+			t.Errorf("Expected %s to include %v, got %v", tt.chord.Name(), tt.chart, voicings)
+		}
+	}
+}
+
+func TestVoicingsFollowTuning(t *testing.T) {
+	dropD := models.StandardTuningMIDI
+	dropD[5] = 38
+
+	power := Chord{Root: 2, Quality: chordQuality("5")}
+	voicings := Voicings(power, dropD)
+	if len(voicings) == 0 || !hasVoicing(voicings, [6]int{0, 0, 0, Muted, Muted, Muted}) {
+		t.Errorf("Expected the open D5 of drop D, got %v", voicings)
+	}
+
+	for _, v := range voicings {
+		for str, fret := range v.Frets {
+			if fret == Muted {
+				continue
+			}
+			if pc := (dropD[str] + fret) % 12; pc != 2 && pc != 9 {
+				t.Errorf("Voicing %v sounds %s, which is not in D5", v.Frets, models.NoteNames[pc])
+			}
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// internal/theory/recognize.go
+package theory
+
+import (
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// Recognition is a chord named from the notes sounding together
+type Recognition struct {
+	Chord Chord
+	Bass  int // Pitch class of the lowest note
+	// Inversion is the place of the bass among the chord tones stacked from
+	// the root: 0 for root position, 1 for first inversion and so on, or -1
+	// when the bass is not a chord tone
+	Inversion int
+}
+
+// Name returns the chord symbol, with the bass after a slash when it is
+// not the root, e.g. "C/E"
+func (r Recognition) Name() string {
+	name := r.Chord.Name()
+	if r.Bass != r.Chord.Root {
+		name += "/" + models.NoteNames[r.Bass]
+	}
+	return name
+}
+
+// Recognize names the chord formed by the given MIDI notes. Chords whose
+// root is in the bass are preferred, then chords with every tone present,
+// then the simpler chord types of the library. A bass note outside the
+// chord is named as a slash bass when the other notes form a triad or more.
+func Recognize(midiNotes []int) (Recognition, bool) {
+	if len(midiNotes) < 2 {
+		return Recognition{}, false
+	}
+
+	lowest := midiNotes[0]
+	present := make(map[int]bool)
+	for _, note := range midiNotes {
+		present[note%12] = true
+		if note < lowest {
+			lowest = note
+		}
+	}
+	bass := lowest % 12
+
+	// Candidates are ranked by a bass outside the chord, then an inversion,
+	// then missing tones, then the order of the chord library
+	var best Recognition
+	var bestRank [4]int
+	found := false
+	consider := func(pcs map[int]bool, outsideBass bool) {
+		for root := 0; root < 12; root++ {
+			if !pcs[root] {
+				continue
+			}
+			for q, quality := range Qualities {
+				chord := Chord{Root: root, Quality: quality}
+				missing, ok := matchChord(chord, pcs)
+				if !ok {
+					continue
+				}
+
+				inversion := -1
+				if !outsideBass {
+					inversion = chord.toneIndex(bass)
+				}
+				rank := [4]int{boolRank(outsideBass), boolRank(inversion != 0), missing, q}
+				if !found || lessRank(rank, bestRank) {
+					best = Recognition{Chord: chord, Bass: bass, Inversion: inversion}
+					bestRank = rank
+					found = true
+				}
+			}
+		}
+	}
+
+	consider(present, false)
+	if !found && len(present) > 3 {
+		upper := make(map[int]bool)
+		for pc := range present {
+			if pc != bass {
+				upper[pc] = true
+			}
+		}
+		consider(upper, true)
+	}
+	return best, found
+}
+
+// boolRank orders false before true
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// lessRank compares two rankings element by element
+func lessRank(a, b [4]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// matchChord reports whether the pitch classes spell the chord: every
+// pitch class is a chord tone and only optional tones are missing. It also
+// returns how many tones are missing.
+func matchChord(chord Chord, pcs map[int]bool) (int, bool) {
+	classes := chord.pitchClasses()
+	for pc := range pcs {
+		if _, ok := classes[pc]; !ok {
+			return 0, false
+		}
+	}
+
+	missing := 0
+	for pc, interval := range classes {
+		if pcs[pc] {
+			continue
+		}
+		optional := false
+		for _, o := range chord.Quality.Optional {
+			if o == interval {
+				optional = true
+			}
+		}
+		if !optional {
+			return 0, false
+		}
+		missing++
+	}
+	return missing, true
+}
+
+// toneIndex returns the place of a pitch class among the chord tones, or
+// -1 if it is not one
+func (c Chord) toneIndex(pc int) int {
+	for i, interval := range c.Quality.Intervals {
+		if (c.Root+interval)%12 == pc {
+			return i
+		}
+	}
+	return -1
+}
+
+// ColumnChords names the chord of every column where two or more notes
+// start together, using the tab's tuning and capo
+func ColumnChords(tab *models.Tab) []models.Annotation {
+	var names []models.Annotation
+	notes := tab.Notes()
+	for i := 0; i < len(notes); {
+		j := i
+		var pitches []int
+		for j < len(notes) && notes[j].Position == notes[i].Position {
+			pitches = append(pitches, tab.MIDINote(notes[j].String, notes[j].Fret))
+			j++
+		}
+		if r, ok := Recognize(pitches); ok {
+			names = append(names, models.Annotation{Position: notes[i].Position, Text: r.Name()})
+		}
+		i = j
+	}
+	return names
+}
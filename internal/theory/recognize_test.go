@@ -0,0 +1,42 @@
+package theory
+
+import "testing"
+
+func TestRecognize(t *testing.T) {
+	tests := []struct {
+		notes     []int
+		name      string
+		inversion int
+	}{
+		{[]int{48, 52, 55}, "C", 0},
+		{[]int{40, 47, 52, 55, 59, 64}, "Em", 0},
+		{[]int{52, 55, 60}, "C/E", 1},
+		{[]int{43, 48, 52}, "C/G", 2},
+		{[]int{50, 57, 60, 66}, "D7", 0},         // Fifth and seventh, no doubling
+		{[]int{48, 52, 58}, "C7", 0},             // Fifth left out
+		{[]int{45, 52}, "A5", 0},                 // Power chord
+		{[]int{45, 48, 52, 55}, "Am7", 0},        // Not C6 with A in the bass
+		{[]int{48, 52, 55, 57}, "C6", 0},         // Not Am7 with C in the bass
+		{[]int{42, 60, 64, 67}, "C/F#", -1},      // Bass outside the chord
+		{[]int{46, 50, 57, 60, 66}, "D7/A#", -1}, // Slash bass under a seventh chord
+	}
+
+	for _, tt := range tests {
+		r, ok := Recognize(tt.notes)
+		if !ok {
+			t.Errorf("Expected %v to be recognized as %s", tt.notes, tt.name)
+			continue
+		}
+		if r.Name() != tt.name || r.Inversion != tt.inversion {
+			t.Errorf("Expected %v to be %s (inversion %d), got %s (inversion %d)",
+				tt.notes, tt.name, tt.inversion, r.Name(), r.Inversion)
+		}
+	}
+
+	if _, ok := Recognize([]int{48, 49}); ok {
+		t.Error("Expected a minor second not to be named")
+	}
+	if _, ok := Recognize([]int{48}); ok {
+		t.Error("Expected a single note not to be named")
+	}
+}
@@ -0,0 +1,161 @@
+// internal/theory/scale.go
+package theory
+
+import (
+	"math"
+	"strings"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// Mode is a scale type described by its intervals above the tonic
+type Mode struct {
+	Name      string
+	Intervals []int
+}
+
+// Modes lists the scales that can be shown on the fretboard
+var Modes = []Mode{
+	{Name: "major", Intervals: []int{0, 2, 4, 5, 7, 9, 11}},
+	{Name: "minor", Intervals: []int{0, 2, 3, 5, 7, 8, 10}},
+	{Name: "dorian", Intervals: []int{0, 2, 3, 5, 7, 9, 10}},
+	{Name: "phrygian", Intervals: []int{0, 1, 3, 5, 7, 8, 10}},
+	{Name: "lydian", Intervals: []int{0, 2, 4, 6, 7, 9, 11}},
+	{Name: "mixolydian", Intervals: []int{0, 2, 4, 5, 7, 9, 10}},
+	{Name: "locrian", Intervals: []int{0, 1, 3, 5, 6, 8, 10}},
+	{Name: "harmonic minor", Intervals: []int{0, 2, 3, 5, 7, 8, 11}},
+	{Name: "major pentatonic", Intervals: []int{0, 2, 4, 7, 9}},
+	{Name: "minor pentatonic", Intervals: []int{0, 3, 5, 7, 10}},
+	{Name: "blues", Intervals: []int{0, 3, 5, 6, 7, 10}},
+}
+
+// modeAliases maps other common names to the modes above
+var modeAliases = map[string]string{
+	"ionian":        "major",
+	"aeolian":       "minor",
+	"natural minor": "minor",
+	"pentatonic":    "minor pentatonic",
+}
+
+// Scale is a tonic pitch class with a mode
+type Scale struct {
+	Tonic int // Pitch class, 0 = C
+	Mode  Mode
+}
+
+// Name returns the scale name, e.g. "A minor pentatonic"
+func (s Scale) Name() string {
+	return models.NoteNames[s.Tonic] + " " + s.Mode.Name
+}
+
+// Degree returns the place of the pitch class in the scale, 0 being the
+// tonic, or -1 if the scale does not contain it
+func (s Scale) Degree(pc int) int {
+	for i, interval := range s.Mode.Intervals {
+		if (s.Tonic+interval)%12 == pc {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseScale reads a scale such as "A minor", "f# dorian" or "Bb blues".
+// A tonic on its own means the major scale.
+func ParseScale(text string) (Scale, bool) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 {
+		return Scale{}, false
+	}
+
+	// Note names are case-insensitive, but "b" as a flat must stay lower case
+	name := strings.ToUpper(fields[0][:1]) + fields[0][1:]
+	tonic, ok := models.ParsePitchClass(name)
+	if !ok {
+		return Scale{}, false
+	}
+
+	mode := strings.Join(fields[1:], " ")
+	if mode == "" {
+		mode = "major"
+	}
+	if alias, ok := modeAliases[mode]; ok {
+		mode = alias
+	}
+	for _, m := range Modes {
+		if m.Name == mode {
+			return Scale{Tonic: tonic, Mode: m}, true
+		}
+	}
+	return Scale{}, false
+}
+
+// Krumhansl-Kessler key profiles: how well each degree fits a major or
+// minor key, from listening experiments
+var (
+	majorProfile = [12]float64{6.35, 2.23, 3.48, 2.33, 4.38, 4.09, 2.52, 5.19, 2.39, 3.66, 2.29, 2.88}
+	minorProfile = [12]float64{6.33, 2.68, 3.52, 5.38, 2.60, 3.53, 2.54, 4.75, 3.98, 2.69, 3.34, 3.17}
+)
+
+// DetectKey estimates the major or minor key of the tab by correlating how
+// long each pitch class sounds with the key profiles. It returns false for
+// a tab without notes.
+func DetectKey(tab *models.Tab) (Scale, bool) {
+	notes := tab.Notes()
+	if len(notes) == 0 {
+		return Scale{}, false
+	}
+
+	// A note lasts until the next column holding a note, at most a measure
+	var weights [12]float64
+	for i, note := range notes {
+		next := tab.GetTotalLength()
+		for _, later := range notes[i+1:] {
+			if later.Position > note.Position {
+				next = later.Position
+				break
+			}
+		}
+		duration := next - note.Position
+		if duration > models.MeasureLength {
+			duration = models.MeasureLength
+		}
+		weights[tab.MIDINote(note.String, note.Fret)%12] += float64(duration)
+	}
+
+	best, bestScore := Scale{}, math.Inf(-1)
+	for _, key := range []struct {
+		mode    Mode
+		profile [12]float64
+	}{{Modes[0], majorProfile}, {Modes[1], minorProfile}} {
+		for tonic := 0; tonic < 12; tonic++ {
+			var rotated [12]float64
+			for pc := 0; pc < 12; pc++ {
+				rotated[pc] = key.profile[(pc-tonic+12)%12]
+			}
+			if score := correlation(weights, rotated); score > bestScore {
+				best, bestScore = Scale{Tonic: tonic, Mode: key.mode}, score
+			}
+		}
+	}
+	return best, true
+}
+
+// correlation returns the Pearson correlation of two pitch class profiles
+func correlation(a, b [12]float64) float64 {
+	var meanA, meanB float64
+	for i := range a {
+		meanA += a[i] / 12
+		meanB += b[i] / 12
+	}
+
+	var cov, varA, varB float64
+	for i := range a {
+		cov += (a[i] - meanA) * (b[i] - meanB)
+		varA += (a[i] - meanA) * (a[i] - meanA)
+		varB += (b[i] - meanB) * (b[i] - meanB)
+	}
+	if varA == 0 || varB == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varA*varB)
+}
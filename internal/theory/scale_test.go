@@ -0,0 +1,49 @@
+package theory
+
+import (
+	"testing"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+func TestParseScale(t *testing.T) {
+	tests := []struct {
+		text string
+		name string
+	}{
+		{"A minor pentatonic", "A minor pentatonic"},
+		{"f# dorian", "F# dorian"},
+		{"bb", "A# major"},
+		{"E aeolian", "E minor"},
+	}
+	for _, tt := range tests {
+		scale, ok := ParseScale(tt.text)
+		if !ok || scale.Name() != tt.name {
+			t.Errorf("ParseScale(%q) = %q, expected %q", tt.text, scale.Name(), tt.name)
+		}
+	}
+
+	for _, text := range []string{"", "H major", "C bebop"} {
+		if _, ok := ParseScale(text); ok {
+			t.Errorf("Expected %q not to parse", text)
+		}
+	}
+}
+
+func TestDetectKey(t *testing.T) {
+	// An A minor pentatonic lick resolving to A on the low E string
+	tab := models.NewEmptyTab("Lick")
+	tab.Content[0] = "5-8-----5-------" + "----------------" + "----------------" + "----------------"
+	tab.Content[1] = "--------8-5-----" + "8-5-------------" + "----------------" + "----------------"
+	tab.Content[2] = "------------7-5-" + "----7-5---------" + "----------------" + "----------------"
+	tab.Content[5] = "----------------" + "--------5-------" + "----------------" + "----------------"
+
+	key, ok := DetectKey(tab)
+	if !ok || key.Name() != "A minor" {
+		t.Errorf("Expected A minor, got %q", key.Name())
+	}
+
+	if _, ok := DetectKey(models.NewEmptyTab("Empty")); ok {
+		t.Error("Expected no key for a tab without notes")
+	}
+}
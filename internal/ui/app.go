@@ -3,6 +3,9 @@ package ui
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -12,8 +15,11 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/Cod-e-Codes/tuitar/internal/audio"
+	"github.com/Cod-e-Codes/tuitar/internal/config"
+	"github.com/Cod-e-Codes/tuitar/internal/formats"
 	"github.com/Cod-e-Codes/tuitar/internal/models"
 	"github.com/Cod-e-Codes/tuitar/internal/storage"
+	"github.com/Cod-e-Codes/tuitar/internal/theory"
 	"github.com/Cod-e-Codes/tuitar/internal/ui/components"
 )
 
@@ -23,8 +29,20 @@ const (
 	inputModeNone inputMode = iota
 	inputModeSave
 	inputModeRename
+	inputModeExport
+	inputModeImport
+	inputModeSection
+	inputModeTranspose
+	inputModeCapo
+	inputModeScale
+	inputModeCommand
+	inputModeSearch
 )
 
+// viewTop is the screen line the tab list and the editor start on, below
+// the title and a blank line
+const viewTop = 2
+
 type Model struct {
 	state       models.SessionState
 	storage     storage.Storage
@@ -35,32 +53,71 @@ type Model struct {
 	tabEditor  components.TabEditorModel
 	tabBrowser components.TabBrowserModel
 	statusBar  components.StatusBarModel
+	outline    components.SectionOutlineModel
+	warnings   components.WarningListModel
+	chords     components.ChordPickerModel
+	fretboard  components.FretboardModel
 	help       help.Model
 	textInput  textinput.Model
 
 	// UI State
-	windowSize tea.WindowSizeMsg
-	showHelp   bool
-	inputMode  inputMode
-	keys       KeyMap
+	windowSize  tea.WindowSizeMsg
+	showHelp    bool
+	showOutline bool
+	showWarns   bool // Playability warnings in the side panel instead of the outline
+	showChords  bool
+	showNeck    bool         // Fretboard panel under the editor
+	scale       theory.Scale // Scale chosen for the fretboard, if scaleChosen
+	scaleChosen bool
+	inputMode   inputMode
+	unroll      bool // Export repeats and jumps written out in playback order
+	keys        KeyMap
+	editorKeys  components.EditorKeyMap
+	shiftScope  models.ShiftScope // Shift scope a tab is opened with
+	follow      bool              // Keep the measures being played on screen
+	theme       components.Theme
+	themeName   string
+	colors      map[string]config.Colors // Colors of the config file laid over the theme
+
+	// Split screen, see split.go
+	split      splitLayout
+	other      components.TabEditorModel // Pane without the focus
+	otherFirst bool                      // The other pane is drawn above or left of the focused one
+
+	// Command line
+	history        []string // Command lines run, oldest first
+	historyIdx     int      // History entry shown, len(history) for a new line
+	completions    []string // Candidates cycled through by Tab
+	completionIdx  int
+	completionBase string // Line before the word being completed
+	searchHistory  []string
 }
 
 type KeyMap struct {
-	Up        key.Binding
-	Down      key.Binding
-	Left      key.Binding
-	Right     key.Binding
-	Enter     key.Binding
-	Quit      key.Binding
-	Help      key.Binding
-	Save      key.Binding
-	New       key.Binding
-	Play      key.Binding
-	Insert    key.Binding
-	Normal    key.Binding
-	Browser   key.Binding
-	Delete    key.Binding
-	DeleteTab key.Binding
+	Enter      key.Binding
+	Quit       key.Binding
+	Help       key.Binding
+	Save       key.Binding
+	New        key.Binding
+	Play       key.Binding
+	Follow     key.Binding
+	Normal     key.Binding
+	Browser    key.Binding
+	DeleteTab  key.Binding
+	Export     key.Binding
+	Import     key.Binding
+	Section    key.Binding
+	Outline    key.Binding
+	Transpose  key.Binding
+	Capo       key.Binding
+	Chords     key.Binding
+	Fretboard  key.Binding
+	Scale      key.Binding
+	Warnings   key.Binding
+	Command    key.Binding
+	Search     key.Binding
+	SearchBack key.Binding
+	Pane       key.Binding
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
@@ -69,79 +126,73 @@ func (k KeyMap) ShortHelp() []key.Binding {
 
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Left, k.Right},
-		{k.Enter, k.Save, k.New},
-		{k.Insert, k.Normal, k.Browser},
-		{k.Play, k.Delete, k.DeleteTab, k.Help, k.Quit},
+		{k.Enter, k.Save, k.New, k.Export, k.Import},
+		{k.Normal, k.Browser, k.Section, k.Outline, k.Transpose, k.Capo, k.Chords, k.Fretboard, k.Scale, k.Warnings},
+		{k.Play, k.Follow, k.DeleteTab, k.Command, k.Search, k.SearchBack, k.Pane, k.Help, k.Quit},
 	}
 }
 
 func NewKeyMap() KeyMap {
 	return KeyMap{
-		Up: key.NewBinding(
-			key.WithKeys("k", "up"),
-			key.WithHelp("↑/k", "move up"),
-		),
-		Down: key.NewBinding(
-			key.WithKeys("j", "down"),
-			key.WithHelp("↓/j", "move down"),
-		),
-		Left: key.NewBinding(
-			key.WithKeys("h", "left"),
-			key.WithHelp("←/h", "move left"),
-		),
-		Right: key.NewBinding(
-			key.WithKeys("l", "right"),
-			key.WithHelp("→/l", "move right"),
-		),
-		Enter: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "select/confirm"),
-		),
-		Quit: key.NewBinding(
-			key.WithKeys("q", "ctrl+c"),
-			key.WithHelp("q", "quit"),
-		),
-		Help: key.NewBinding(
-			key.WithKeys("?"),
-			key.WithHelp("?", "toggle help"),
-		),
-		Save: key.NewBinding(
-			key.WithKeys("ctrl+s"),
-			key.WithHelp("ctrl+s", "save tab"),
-		),
-		New: key.NewBinding(
-			key.WithKeys("ctrl+n"),
-			key.WithHelp("ctrl+n", "new tab"),
-		),
-		Play: key.NewBinding(
-			key.WithKeys(" "),
-			key.WithHelp("space", "play/pause"),
-		),
-		Insert: key.NewBinding(
-			key.WithKeys("i"),
-			key.WithHelp("i", "insert mode"),
-		),
-		Normal: key.NewBinding(
-			key.WithKeys("esc"),
-			key.WithHelp("esc", "normal mode"),
-		),
-		Browser: key.NewBinding(
-			key.WithKeys("tab"),
-			key.WithHelp("tab", "browser/editor"),
-		),
-		Delete: key.NewBinding(
-			key.WithKeys("x"),
-			key.WithHelp("x", "delete fret"),
-		),
-		DeleteTab: key.NewBinding(
-			key.WithKeys("d"),
-			key.WithHelp("d", "delete tab"),
-		),
-	}
-}
-
-func NewModel(storage storage.Storage) Model {
+		Enter:      components.NewBinding("select/confirm", "enter"),
+		Quit:       components.NewBinding("quit (only Ctrl+C in the editor)", "q", "ctrl+c"),
+		Help:       components.NewBinding("toggle this help", "?", "f1"),
+		Save:       components.NewBinding("save tab", "ctrl+s"),
+		New:        components.NewBinding("new tab", "ctrl+n"),
+		Play:       components.NewBinding("play/pause", " "),
+		Follow:     components.NewBinding("scroll along with playback on/off", "ctrl+f"),
+		Normal:     components.NewBinding("leave a side panel", "esc"),
+		Browser:    components.NewBinding("browser/editor", "tab"),
+		DeleteTab:  components.NewBinding("delete tab", "d"),
+		Export:     components.NewBinding("export tab (Ctrl+U in the dialog unrolls repeats)", "ctrl+e"),
+		Import:     components.NewBinding("import tab", "ctrl+o"),
+		Section:    components.NewBinding("start/rename section at measure", "S"),
+		Outline:    components.NewBinding("section outline (Enter: jump)", "o"),
+		Transpose:  components.NewBinding("transpose the tab (or selection)", "T"),
+		Capo:       components.NewBinding("set the capo fret", "K"),
+		Chords:     components.NewBinding("insert a chord shape (Space: listen)", "H"),
+		Fretboard:  components.NewBinding("fretboard: enter notes by fret", "F"),
+		Warnings:   components.NewBinding("playability warnings (Enter: jump)", "W"),
+		Scale:      components.NewBinding("choose the fretboard scale (empty: detect)", "ctrl+k"),
+		Command:    components.NewBinding("command line (Tab: complete, ↑/↓: history)", ":"),
+		Search:     components.NewBinding("search for a fret, note or lick", "/"),
+		SearchBack: components.NewBinding("search backward", "?"),
+		Pane:       components.NewBinding("switch the split pane (:split, :vsplit)", "ctrl+w"),
+	}
+}
+
+// Bindings returns the bindings by the names used in the config file
+func (k *KeyMap) Bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"enter": &k.Enter, "quit": &k.Quit, "help": &k.Help, "save": &k.Save, "new": &k.New,
+		"play": &k.Play, "follow": &k.Follow, "back": &k.Normal, "browser": &k.Browser, "delete_tab": &k.DeleteTab,
+		"export": &k.Export, "import": &k.Import, "section": &k.Section, "outline": &k.Outline,
+		"transpose": &k.Transpose, "capo": &k.Capo, "chords": &k.Chords, "fretboard": &k.Fretboard,
+		"scale": &k.Scale, "warnings": &k.Warnings, "command": &k.Command,
+		"search": &k.Search, "search_back": &k.SearchBack, "pane": &k.Pane,
+	}
+}
+
+// applyKeys rebinds the actions named in the config file, returning the
+// names it does not know
+func (m *Model) applyKeys(keys map[string]config.Keys) []string {
+	bindings := m.keys.Bindings()
+	for name, b := range m.editorKeys.Bindings() {
+		bindings[name] = b
+	}
+	var unknown []string
+	for name, k := range keys {
+		if b, ok := bindings[name]; ok {
+			components.Rebind(b, k)
+		} else {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+func NewModel(storage storage.Storage, cfg config.Config) Model {
 	tabs, _ := storage.LoadAllTabs()
 
 	textInput := textinput.New()
@@ -152,9 +203,18 @@ func NewModel(storage storage.Storage) Model {
 		storage:     storage,
 		tabs:        tabs,
 		keys:        NewKeyMap(),
+		editorKeys:  components.NewEditorKeyMap(),
+		theme:       components.DarkTheme(),
+		themeName:   "dark",
+		colors:      cfg.Colors,
+		follow:      cfg.Follow,
 		help:        help.New(),
 		tabBrowser:  components.NewTabBrowser(tabs),
 		statusBar:   components.NewStatusBar(),
+		outline:     components.NewSectionOutline(),
+		warnings:    components.NewWarningList(),
+		chords:      components.NewChordPicker(),
+		fretboard:   components.NewFretboard(),
 		textInput:   textInput,
 		audioPlayer: audio.NewPlayer(),
 	}
@@ -162,6 +222,19 @@ func NewModel(storage storage.Storage) Model {
 	m.state.ViewMode = models.ViewBrowser
 	m.state.EditMode = models.EditNormal
 
+	m.warnings.SetMaxStretch(cfg.MaxStretch)
+	m.shiftScope, _ = cfg.Scope()
+	var problems []string
+	if unknown := m.applyKeys(cfg.Keys); len(unknown) > 0 {
+		problems = append(problems, "Unknown actions in the config keys: "+strings.Join(unknown, ", "))
+	}
+	if err := m.setTheme(cfg.Theme); err != nil {
+		problems = append(problems, "Config: "+err.Error())
+	}
+	if len(problems) > 0 {
+		m.statusBar.SetStatus(strings.Join(problems, "; "))
+	}
+
 	return m
 }
 
@@ -188,34 +261,87 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tickMsg:
 		// Update playback highlights if playing
 		if m.audioPlayer.IsPlaying() && m.state.ViewMode == models.ViewEditor {
-			highlighted := m.audioPlayer.GetHighlighted()
-			m.tabEditor.SetHighlightedPositions(highlighted)
+			m.tabEditor.SetHighlightedPositions(m.audioPlayer.GetHighlighted())
+			// Follow the column played, so the view keeps moving through rests
+			if column, ok := m.audioPlayer.PlayingColumn(); m.follow && ok {
+				m.tabEditor.ShowColumn(column)
+			}
 		}
 		return m, m.tick()
 
 	case tea.WindowSizeMsg:
 		m.windowSize = msg
-		m.tabEditor.SetSize(msg.Width, msg.Height-3)
+		m.resizeEditor()
 		m.tabBrowser.SetSize(msg.Width, msg.Height-3)
 
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
+
 	case tea.KeyMsg:
 		// Handle input mode first
+		if m.inputMode == inputModeCommand || m.inputMode == inputModeSearch {
+			return m.updateCommandLine(msg)
+		}
 		if m.inputMode != inputModeNone {
 			return m.updateInput(msg)
 		}
 
+		// Chord and lyric lanes take typed text, and two-key commands take
+		// their second key, so only save and Ctrl+C stay global
+		if m.state.ViewMode == models.ViewEditor && (m.tabEditor.InTextEntry() || m.tabEditor.PendingKey()) &&
+			!key.Matches(msg, m.keys.Save) && msg.Type != tea.KeyCtrlC {
+			return m.updateEditor(msg)
+		}
+
+		if m.state.ViewMode == models.ViewEditor && m.showOutline && m.outline.Focused() && msg.Type != tea.KeyCtrlC {
+			return m.updateOutline(msg)
+		}
+
+		if m.state.ViewMode == models.ViewEditor && m.showWarns && m.warnings.Focused() && msg.Type != tea.KeyCtrlC {
+			return m.updateWarnings(msg)
+		}
+
+		if m.state.ViewMode == models.ViewEditor && m.showNeck && m.fretboard.Focused() && msg.Type != tea.KeyCtrlC {
+			return m.updateFretboard(msg)
+		}
+
+		if m.state.ViewMode == models.ViewEditor && m.showChords && msg.Type != tea.KeyCtrlC {
+			return m.updateChords(msg)
+		}
+
+		if m.state.ViewMode == models.ViewEditor && m.unrecordable(msg) {
+			m.statusBar.SetStatus("Macros record editor keys only: stop recording with q first")
+			return m, nil
+		}
+
 		switch {
-		case key.Matches(msg, m.keys.Quit):
+		// In the editor q records macros; Ctrl+C and :q still quit
+		case key.Matches(msg, m.keys.Quit) && (msg.Type == tea.KeyCtrlC || m.state.ViewMode != models.ViewEditor):
 			if m.audioPlayer.IsPlaying() {
 				m.audioPlayer.Stop()
 			}
 			return m, tea.Quit
 
+		// In the editor ? searches backward, so help is on F1 there
+		case key.Matches(msg, m.keys.Search, m.keys.SearchBack) && m.state.ViewMode == models.ViewEditor &&
+			m.state.EditMode == models.EditNormal && !m.showHelp:
+			m.openSearch(key.Matches(msg, m.keys.SearchBack))
+			return m, nil
+
 		case key.Matches(msg, m.keys.Help):
 			m.showHelp = !m.showHelp
 			return m, nil
 
-		case key.Matches(msg, m.keys.Browser):
+		case key.Matches(msg, m.keys.Command) &&
+			(m.state.ViewMode == models.ViewBrowser || m.state.EditMode == models.EditNormal):
+			m.showHelp = false
+			m.openCommandLine()
+			return m, nil
+
+		// Ctrl+I arrives as Tab, so Tab returns from Ctrl+O before it
+		// switches to the browser
+		case key.Matches(msg, m.keys.Browser) && !(m.state.ViewMode == models.ViewEditor &&
+			m.state.EditMode == models.EditNormal && m.tabEditor.HasNewerJump()):
 			// Toggle between browser and editor
 			if m.state.ViewMode == models.ViewBrowser {
 				if m.state.CurrentTab != nil {
@@ -227,12 +353,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case key.Matches(msg, m.keys.New):
-			newTab := models.NewTestTab("New Tab")
-			m.state.CurrentTab = newTab
-			m.tabEditor = components.NewTabEditor(newTab)
-			m.tabEditor.SetEditMode(models.EditNormal)
-			m.state.ViewMode = models.ViewEditor
-			m.state.EditMode = models.EditNormal
+			m.openEditor(models.NewTestTab("New Tab"))
 			m.statusBar.SetStatus("Created new tab with test notes")
 			return m, nil
 
@@ -250,6 +371,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case key.Matches(msg, m.keys.Export):
+			if m.state.ViewMode == models.ViewEditor && m.state.CurrentTab != nil {
+				m.inputMode = inputModeExport
+				m.textInput.SetValue(exportFileName(m.state.CurrentTab.Name) + ".musicxml")
+				m.textInput.Focus()
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Follow) && m.state.ViewMode == models.ViewEditor:
+			m.follow = !m.follow
+			if m.follow {
+				m.statusBar.SetStatus("Following playback")
+			} else {
+				m.statusBar.SetStatus("Not following playback")
+			}
+			return m, nil
+
 		case key.Matches(msg, m.keys.Play):
 			if m.state.ViewMode == models.ViewEditor && m.state.CurrentTab != nil {
 				if m.audioPlayer.IsPlaying() {
@@ -286,12 +424,48 @@ func (m Model) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.textInput.Blur()
 		return m, nil
 
+	case tea.KeyCtrlU:
+		if m.inputMode == inputModeExport {
+			m.unroll = !m.unroll
+			return m, nil
+		}
+
+	case tea.KeyCtrlR:
+		if m.inputMode == inputModeCapo {
+			m.setCapo(m.textInput.Value(), true)
+			m.inputMode = inputModeNone
+			m.textInput.Blur()
+			m.textInput.SetValue("")
+			return m, nil
+		}
+
 	case tea.KeyEnter:
 		value := m.textInput.Value()
-		if value != "" {
-			if m.inputMode == inputModeSave {
+		switch {
+		case m.inputMode == inputModeSection:
+			// An empty name removes the section
+			m.setSection(strings.TrimSpace(value))
+		case m.inputMode == inputModeScale:
+			// An empty scale goes back to the detected key
+			m.setScale(value)
+		case value != "":
+			switch m.inputMode {
+			case inputModeSave:
 				m.state.CurrentTab.Name = value
 				m.saveCurrentTab()
+			case inputModeExport:
+				m.exportCurrentTab(value)
+			case inputModeImport:
+				m.importTab(value)
+			case inputModeCapo:
+				m.setCapo(value, false)
+			case inputModeTranspose:
+				if semitones, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+					m.statusBar.SetStatus("Not a number of semitones: " + value)
+				} else {
+					m.tabEditor.Transpose(semitones)
+					m.statusBar.SetStatus(m.tabEditor.Status())
+				}
 			}
 		}
 		m.inputMode = inputModeNone
@@ -305,7 +479,7 @@ func (m Model) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m *Model) saveCurrentTab() {
+func (m *Model) saveCurrentTab() error {
 	err := m.storage.SaveTab(m.state.CurrentTab)
 	if err != nil {
 		m.statusBar.SetStatus("Error saving tab: " + err.Error())
@@ -317,23 +491,384 @@ func (m *Model) saveCurrentTab() {
 			m.tabBrowser.SetTabs(tabs)
 		}
 	}
+	return err
 }
 
-func (m Model) updateBrowser(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// setScale chooses the scale shown on the fretboard. An empty value goes
+// back to the key detected from the tab.
+func (m *Model) setScale(value string) {
+	if strings.TrimSpace(value) == "" {
+		m.scaleChosen = false
+		m.statusBar.SetStatus("Fretboard shows the detected key")
+		return
+	}
+	scale, ok := theory.ParseScale(value)
+	if !ok {
+		m.statusBar.SetStatus("Unknown scale: " + value)
+		return
+	}
+	m.scale = scale
+	m.scaleChosen = true
+	m.statusBar.SetStatus("Fretboard shows " + scale.Name())
+	if !m.showNeck {
+		m.showNeck = true
+		m.resizeEditor()
+	}
+}
+
+// setCapo places the capo at the fret given in value. With rewrite, frets
+// are rewritten relative to the new capo so the notes keep their pitch.
+func (m *Model) setCapo(value string, rewrite bool) {
+	capo, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || capo < 0 || capo > models.MaxFret {
+		m.statusBar.SetStatus(fmt.Sprintf("Capo must be a fret from 0 to %d", models.MaxFret))
+		return
+	}
+
+	tab := m.state.CurrentTab
+	if !rewrite {
+		tab.Capo = capo
+		tab.UpdatedAt = time.Now()
+		m.statusBar.SetStatus(fmt.Sprintf("Capo set to fret %d", capo))
+		return
+	}
+
+	status := fmt.Sprintf("Capo moved to fret %d, frets rewritten to keep the pitch", capo)
+	if failed := tab.RewriteForCapo(capo); failed > 0 {
+		status += fmt.Sprintf("; %d note(s) left as they were", failed)
+	}
+	m.statusBar.SetStatus(status)
+}
+
+// setSection starts, renames or (with an empty name) removes the section at
+// the editor cursor
+func (m *Model) setSection(name string) {
+	tab := m.state.CurrentTab
+	measure := m.tabEditor.GetCursor().Position / models.MeasureLength
+	if name == "" {
+		if tab.SectionAt(measure) != -1 {
+			m.statusBar.SetStatus("Removed section at measure " + fmt.Sprint(measure+1))
+		}
+		tab.RemoveSection(measure)
+		return
+	}
+	tab.MarkSection(measure, name)
+	m.statusBar.SetStatus(fmt.Sprintf("Section %q starts at measure %d", name, measure+1))
+}
+
+// resizeEditor fits the editor next to the side panel and above the
+// fretboard when they are shown
+func (m *Model) resizeEditor() {
+	width, height := m.editorArea()
+	m.resizePanes(width, height)
+	m.fretboard.SetSize(m.windowSize.Width)
+	m.warnings.SetHeight(height)
+}
+
+// editorArea returns the size left to the editor panes by the side and
+// bottom panels
+func (m Model) editorArea() (width, height int) {
+	width = m.windowSize.Width
+	if m.showOutline || m.showWarns {
+		width -= components.OutlineWidth
+	}
+	height = m.windowSize.Height - 3
+	if m.showNeck {
+		height -= components.FretboardHeight
+	}
+	return width, height
+}
+
+// setTheme styles the views with a built-in theme and the colors of the
+// config file. A color for an unknown role is skipped and reported.
+func (m *Model) setTheme(name string) error {
+	theme, ok := components.ThemeNamed(name)
+	if !ok {
+		return fmt.Errorf("unknown theme %q, expected %s", name, strings.Join(components.ThemeNames, ", "))
+	}
+	roles := make([]string, 0, len(m.colors))
+	for role := range m.colors {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	var err error
+	for _, role := range roles {
+		colors := m.colors[role]
+		if roleErr := theme.SetColors(role, colors.Fg, colors.Bg); roleErr != nil && err == nil {
+			err = roleErr
+		}
+	}
+
+	m.theme, m.themeName = theme, name
+	m.tabEditor.SetTheme(theme)
+	m.other.SetTheme(theme)
+	m.tabBrowser.SetTheme(theme)
+	m.statusBar.SetTheme(theme)
+	m.outline.SetTheme(theme)
+	m.warnings.SetTheme(theme)
+	m.chords.SetTheme(theme)
+	m.fretboard.SetTheme(theme)
+	return err
+}
+
+// openEditor switches to the editor on a tab. The register and macros
+// carry over from the tab edited before.
+func (m *Model) openEditor(tab *models.Tab) {
+	register, macros := m.tabEditor.Register(), m.tabEditor.Macros()
+	m.state.CurrentTab = tab
+	m.tabEditor = components.NewTabEditor(tab)
+	m.tabEditor.SetKeyMap(m.editorKeys)
+	m.tabEditor.SetTheme(m.theme)
+	m.tabEditor.SetShiftScope(m.shiftScope)
+	m.tabEditor.SetMaxStretch(m.warnings.MaxStretch())
+	m.tabEditor.SetEditMode(models.EditNormal)
+	m.tabEditor.SetRegister(register)
+	m.tabEditor.SetMacros(macros)
+	m.resizeEditor()
+	m.state.ViewMode = models.ViewEditor
+	m.state.EditMode = models.EditNormal
+}
+
+func (m Model) updateWarnings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tab := m.state.CurrentTab
+	m.warnings.SetWarnings(tab.Playability(m.warnings.MaxStretch()), tab.Tuning)
+
+	switch {
+	case key.Matches(msg, m.keys.Warnings):
+		m.warnings.Blur()
+		m.showWarns = false
+		m.resizeEditor()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Normal):
+		m.warnings.Blur()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		if w, ok := m.warnings.Selected(); ok {
+			target := models.Position{String: m.tabEditor.GetCursor().String, Position: w.Position}
+			if w.String >= 0 {
+				target.String = w.String
+			}
+			m.tabEditor.JumpTo(target)
+			m.statusBar.SetStatus(w.Message)
+		}
+		m.warnings.Blur()
+		return m, nil
+	}
+
 	var cmd tea.Cmd
+	m.warnings, cmd = m.warnings.Update(msg)
+	m.tabEditor.SetMaxStretch(m.warnings.MaxStretch())
+	return m, cmd
+}
+
+func (m Model) updateOutline(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tab := m.state.CurrentTab
+	measure := m.tabEditor.GetCursor().Position / models.MeasureLength
+	m.outline.SetSections(tab.Sections, tab.SectionAt(measure))
 
 	switch {
+	case key.Matches(msg, m.keys.Outline):
+		m.outline.Blur()
+		m.showOutline = false
+		m.resizeEditor()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Normal):
+		m.outline.Blur()
+		return m, nil
+
 	case key.Matches(msg, m.keys.Enter):
-		if len(m.tabs) > 0 && m.tabBrowser.Cursor() < len(m.tabs) {
-			selectedTab := &m.tabs[m.tabBrowser.Cursor()]
-			tabCopy := *selectedTab
-			m.state.CurrentTab = &tabCopy
-			m.tabEditor = components.NewTabEditor(&tabCopy)
-			m.tabEditor.SetEditMode(models.EditNormal)
-			m.state.ViewMode = models.ViewEditor
-			m.state.EditMode = models.EditNormal
-			m.statusBar.SetStatus("Editing: " + tabCopy.Name)
+		if section, ok := m.outline.Selected(); ok {
+			m.tabEditor.JumpToMeasure(section.Start)
+			m.statusBar.SetStatus("Jumped to " + section.Name)
+		}
+		m.outline.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.outline, cmd = m.outline.Update(msg)
+	return m, cmd
+}
+
+// updateFretboard enters notes at the editor cursor column with the
+// fretboard cursor; the editor follows its string
+func (m Model) updateFretboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	str, fret := m.fretboard.Cursor()
+	tab := m.state.CurrentTab
+
+	switch {
+	case key.Matches(msg, m.keys.Fretboard):
+		m.fretboard.Blur()
+		m.showNeck = false
+		m.resizeEditor()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Normal):
+		m.fretboard.Blur()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		m.tabEditor.PlaceFret(str, fret)
+		if status := m.tabEditor.Status(); status != "" {
+			m.statusBar.SetStatus(status)
+		} else {
+			m.audioPlayer.PlayChord([]int{tab.MIDINote(str, fret)})
+		}
+		m.state.CurrentTab = m.tabEditor.GetTab()
+		return m, nil
+
+	case key.Matches(msg, m.editorKeys.DeleteFret):
+		m.tabEditor.ClearFret(str)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Play):
+		m.tabEditor.StepColumn(1)
+		return m, nil
+
+	case key.Matches(msg, m.editorKeys.Backspace):
+		m.tabEditor.StepColumn(-1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.fretboard, cmd = m.fretboard.Update(msg)
+	if newStr, _ := m.fretboard.Cursor(); newStr != str {
+		m.tabEditor.SetCursorString(newStr)
+	}
+	return m, cmd
+}
+
+func (m Model) updateChords(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Chords), key.Matches(msg, m.keys.Normal):
+		m.showChords = false
+		return m, nil
+
+	case key.Matches(msg, m.keys.Play):
+		m.audioPlayer.PlayChord(m.chords.MIDINotes())
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		if voicing, ok := m.chords.Voicing(); ok {
+			m.tabEditor.StampVoicing(m.chords.Chord().Name(), voicing.Frets)
+			m.statusBar.SetStatus(m.tabEditor.Status())
+			m.state.CurrentTab = m.tabEditor.GetTab()
 		}
+		m.showChords = false
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.chords, cmd = m.chords.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) exportCurrentTab(path string) {
+	tab := m.state.CurrentTab
+	if m.unroll {
+		tab = tab.Unrolled()
+	}
+	if err := formats.ExportFile(tab, path); err != nil {
+		m.statusBar.SetStatus("Error exporting tab: " + err.Error())
+	} else {
+		m.statusBar.SetStatus("Exported tab to " + path)
+	}
+}
+
+func (m *Model) importTab(path string) {
+	tab, err := formats.ImportFile(path)
+	if err != nil {
+		m.statusBar.SetStatus("Error importing tab: " + err.Error())
+		return
+	}
+
+	m.openEditor(tab)
+	m.statusBar.SetStatus("Imported tab: " + tab.Name + " (Ctrl+S to save)")
+}
+
+// exportFileName turns a tab name into a file name without extension
+func exportFileName(name string) string {
+	fileName := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r == ' ':
+			return '_'
+		}
+		return -1
+	}, name)
+	if fileName == "" {
+		fileName = "tab"
+	}
+	return fileName
+}
+
+// openSelected opens the tab under the browser cursor, shared with the
+// other pane when it is open there
+func (m *Model) openSelected() {
+	if len(m.tabs) > 0 && m.tabBrowser.Cursor() < len(m.tabs) {
+		tab := m.savedTab(m.tabBrowser.Cursor(), m.other.GetTab())
+		m.openEditor(tab)
+		m.statusBar.SetStatus("Editing: " + tab.Name)
+	}
+}
+
+// updateMouse routes clicks, drags and the wheel to the tab list or the
+// editor
+func (m Model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.inputMode != inputModeNone || m.showHelp || m.showChords {
+		return m, nil
+	}
+	msg.Y -= viewTop
+
+	switch m.state.ViewMode {
+	case models.ViewBrowser:
+		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			// A click selects a tab, a second click opens it
+			if row, ok := m.tabBrowser.RowAt(msg.Y); ok {
+				if row == m.tabBrowser.Cursor() {
+					m.openSelected()
+				} else {
+					m.tabBrowser.SetCursor(row)
+				}
+			}
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.tabBrowser, cmd = m.tabBrowser.Update(msg)
+		return m, cmd
+
+	case models.ViewEditor:
+		if m.state.CurrentTab == nil {
+			return m, nil
+		}
+		if m.split != splitNone {
+			return m.updatePaneMouse(msg)
+		}
+		var cmd tea.Cmd
+		m.tabEditor, cmd = m.tabEditor.Update(msg)
+		m.syncEditor()
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m Model) updateBrowser(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch {
+	case key.Matches(msg, m.keys.Enter):
+		m.openSelected()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Import):
+		m.inputMode = inputModeImport
+		m.textInput.SetValue("")
+		m.textInput.Focus()
 		return m, nil
 
 	case key.Matches(msg, m.keys.DeleteTab):
@@ -362,37 +897,149 @@ func (m Model) updateBrowser(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// unrecordable reports whether the key opens the command line, a search,
+// a prompt or a panel while a macro is being recorded. The app handles
+// those keys itself, so the macro would play back without them.
+func (m Model) unrecordable(msg tea.KeyMsg) bool {
+	if _, ok := m.tabEditor.Recording(); !ok {
+		return false
+	}
+	switch m.state.EditMode {
+	case models.EditNormal:
+		return key.Matches(msg, m.keys.Command, m.keys.Search, m.keys.SearchBack, m.keys.Section,
+			m.keys.Outline, m.keys.Transpose, m.keys.Capo, m.keys.Chords, m.keys.Fretboard,
+			m.keys.Scale, m.keys.Warnings, m.keys.Pane)
+	case models.EditSelect:
+		return key.Matches(msg, m.keys.Transpose)
+	}
+	return false
+}
+
 func (m Model) updateEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch {
-	case key.Matches(msg, m.keys.Insert):
-		m.state.EditMode = models.EditInsert
-		m.tabEditor.SetEditMode(models.EditInsert)
-		m.statusBar.SetStatus("-- INSERT MODE --")
+	case m.tabEditor.PendingKey():
+		// The second key of a two-key command belongs to the editor
+
+	case key.Matches(msg, m.keys.Section) && m.state.EditMode == models.EditNormal:
+		tab := m.state.CurrentTab
+		measure := m.tabEditor.GetCursor().Position / models.MeasureLength
+		name := ""
+		if idx := tab.SectionAt(measure); idx != -1 && tab.Sections[idx].Start == measure {
+			name = tab.Sections[idx].Name
+		}
+		m.inputMode = inputModeSection
+		m.textInput.SetValue(name)
+		m.textInput.Focus()
 		return m, nil
 
-	case key.Matches(msg, m.keys.Normal):
-		m.state.EditMode = models.EditNormal
-		m.tabEditor.SetEditMode(models.EditNormal)
-		m.statusBar.SetStatus("-- NORMAL MODE --")
+	case key.Matches(msg, m.keys.Transpose) &&
+		(m.state.EditMode == models.EditNormal || m.state.EditMode == models.EditSelect):
+		m.inputMode = inputModeTranspose
+		m.textInput.SetValue("")
+		m.textInput.Focus()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Pane) && m.state.EditMode == models.EditNormal:
+		if m.split == splitNone {
+			m.statusBar.SetStatus("No other pane: :split or :vsplit opens one")
+		} else {
+			m.switchPane()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Capo) && m.state.EditMode == models.EditNormal:
+		m.inputMode = inputModeCapo
+		m.textInput.SetValue(strconv.Itoa(m.state.CurrentTab.Capo))
+		m.textInput.Focus()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Chords) && m.state.EditMode == models.EditNormal:
+		if m.audioPlayer.IsPlaying() {
+			m.statusBar.SetStatus("Stop playback to insert a chord")
+			return m, nil
+		}
+		m.chords.SetTab(m.state.CurrentTab)
+		m.showChords = true
+		return m, nil
+
+	case key.Matches(msg, m.keys.Fretboard) && m.state.EditMode == models.EditNormal:
+		m.showNeck = true
+		m.resizeEditor()
+		cursor := m.tabEditor.GetCursor()
+		fret := 0
+		if note, ok := m.state.CurrentTab.NoteAt(cursor.String, cursor.Position); ok {
+			fret = note.Fret
+		}
+		m.fretboard.Focus(cursor.String, fret)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Scale) && m.state.EditMode == models.EditNormal:
+		m.inputMode = inputModeScale
+		m.textInput.SetValue("")
+		if m.scaleChosen {
+			m.textInput.SetValue(m.scale.Name())
+		}
+		m.textInput.Focus()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Warnings) && m.state.EditMode == models.EditNormal:
+		m.showWarns = true
+		m.showOutline = false
+		m.resizeEditor()
+		tab := m.state.CurrentTab
+		m.warnings.SetWarnings(tab.Playability(m.warnings.MaxStretch()), tab.Tuning)
+		m.warnings.Focus()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Outline) && m.state.EditMode == models.EditNormal:
+		m.showWarns = false
+		m.showOutline = true
+		m.resizeEditor()
+		tab := m.state.CurrentTab
+		m.outline.SetSections(tab.Sections, tab.SectionAt(m.tabEditor.GetCursor().Position/models.MeasureLength))
+		m.outline.Focus()
 		return m, nil
 	}
 
 	// Pass the message to the tab editor
 	m.tabEditor, cmd = m.tabEditor.Update(msg)
+	m.syncEditor()
+	return m, cmd
+}
+
+// syncEditor reports the mode and status of the editor after it handled
+// a message and takes the tab it changed
+func (m *Model) syncEditor() {
+	if mode := m.tabEditor.GetEditMode(); mode != m.state.EditMode {
+		m.state.EditMode = mode
+		switch mode {
+		case models.EditInsert:
+			m.statusBar.SetStatus("-- INSERT MODE --")
+		case models.EditChord:
+			m.statusBar.SetStatus("-- CHORD MODE --")
+		case models.EditLyric:
+			m.statusBar.SetStatus("-- LYRIC MODE --")
+		case models.EditSelect:
+			m.statusBar.SetStatus("-- SELECT MODE --")
+		case models.EditNormal:
+			m.statusBar.SetStatus("-- NORMAL MODE --")
+		}
+	}
+	if status := m.tabEditor.Status(); status != "" {
+		m.statusBar.SetStatus(status)
+	}
 
 	// Update the current tab if it has changed
 	if m.tabEditor.HasChanged() {
 		m.state.CurrentTab = m.tabEditor.GetTab()
 	}
-
-	return m, cmd
 }
 
 func (m Model) View() string {
-	// Handle input dialogs
-	if m.inputMode != inputModeNone {
+	// Handle input dialogs; the command line replaces the status bar
+	if m.inputMode != inputModeNone && m.inputMode != inputModeCommand && m.inputMode != inputModeSearch {
 		return m.renderInputDialog()
 	}
 
@@ -400,6 +1047,10 @@ func (m Model) View() string {
 		return m.renderHelp()
 	}
 
+	if m.showChords && m.state.ViewMode == models.ViewEditor {
+		return m.renderChordPicker()
+	}
+
 	var content string
 	switch m.state.ViewMode {
 	case models.ViewBrowser:
@@ -409,21 +1060,49 @@ func (m Model) View() string {
 	}
 
 	statusBar := m.statusBar.View()
+	if m.inputMode == inputModeCommand || m.inputMode == inputModeSearch {
+		statusBar = m.renderCommandLine()
+	}
 	return lipgloss.JoinVertical(lipgloss.Left, content, statusBar)
 }
 
 func (m Model) renderInputDialog() string {
 	var title string
+	hint := "Enter: Save • Esc: Cancel"
 	switch m.inputMode {
 	case inputModeSave:
 		title = "Save Tab As:"
 	case inputModeRename:
 		title = "Rename Tab:"
+	case inputModeExport:
+		title = "Export Tab To (.musicxml, .mid, .ly, .atex, .cho, .txt, .svg, .html):"
+		hint = "Enter: Export • Ctrl+U: Unroll repeats (off) • Esc: Cancel"
+		if m.unroll {
+			hint = "Enter: Export • Ctrl+U: Unroll repeats (on) • Esc: Cancel"
+		}
+	case inputModeImport:
+		title = "Import Tab From (.musicxml, .mxl):"
+		hint = "Enter: Import • Esc: Cancel"
+	case inputModeScale:
+		title = "Fretboard Scale (e.g. A minor pentatonic):"
+		hint = "Enter: Set (empty uses the detected key) • Esc: Cancel"
+	case inputModeCapo:
+		title = "Capo Fret (0 for none):"
+		hint = "Enter: Set • Ctrl+R: Set and rewrite frets to keep pitches • Esc: Cancel"
+	case inputModeTranspose:
+		title = "Transpose Tab by Semitones (e.g. 2, -3):"
+		if m.state.EditMode == models.EditSelect {
+			title = "Transpose Selection by Semitones (e.g. 2, -3):"
+		}
+		hint = "Enter: Transpose • Esc: Cancel"
+	case inputModeSection:
+		title = fmt.Sprintf("Section Starting at Measure %d:", m.tabEditor.GetCursor().Position/models.MeasureLength+1)
+		hint = "Enter: Set (empty removes) • Esc: Cancel"
 	}
 
 	dialog := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("12")).
+		BorderForeground(m.theme.Title.GetForeground()).
 		Padding(1, 2).
 		Width(50).
 		Render(lipgloss.JoinVertical(lipgloss.Left,
@@ -431,65 +1110,164 @@ func (m Model) renderInputDialog() string {
 			"",
 			m.textInput.View(),
 			"",
-			lipgloss.NewStyle().Faint(true).Render("Enter: Save • Esc: Cancel"),
+			lipgloss.NewStyle().Faint(true).Render(hint),
 		))
 
 	return lipgloss.Place(m.windowSize.Width, m.windowSize.Height,
 		lipgloss.Center, lipgloss.Center, dialog)
 }
 
-func (m Model) renderHelp() string {
-	helpContent := lipgloss.NewStyle().
-		Padding(1).
+// renderFretboard draws the fretboard panel with the chosen or detected
+// scale and the notes at the editor cursor and under playback
+func (m Model) renderFretboard() string {
+	tab := m.state.CurrentTab
+	fretboard := m.fretboard
+	fretboard.SetTab(tab)
+
+	if m.scaleChosen {
+		fretboard.SetScale(m.scale, false)
+	} else if detected, ok := theory.DetectKey(tab); ok {
+		fretboard.SetScale(detected, true)
+	} else {
+		fretboard.ClearScale()
+	}
+
+	var cursor, playing []models.Note
+	column := m.tabEditor.GetCursor().Position
+	for str := 0; str < 6; str++ {
+		if note, ok := tab.NoteAt(str, column); ok {
+			cursor = append(cursor, note)
+		}
+	}
+	if m.audioPlayer.IsPlaying() {
+		for _, pos := range m.audioPlayer.GetHighlighted() {
+			if note, ok := tab.NoteAt(pos.String, pos.Position); ok {
+				playing = append(playing, note)
+			}
+		}
+	}
+	fretboard.SetNotes(cursor, playing)
+	return fretboard.View()
+}
+
+func (m Model) renderChordPicker() string {
+	title := fmt.Sprintf("Insert Chord at Column %d:", m.tabEditor.GetCursor().Position+1)
+	if capo := m.state.CurrentTab.Capo; capo > 0 {
+		title = fmt.Sprintf("Insert Chord at Column %d (capo %d):", m.tabEditor.GetCursor().Position+1, capo)
+	}
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.Title.GetForeground()).
+		Padding(1, 2).
 		Render(lipgloss.JoinVertical(lipgloss.Left,
-			lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12")).Render("Tuitar - Guitar Tab Editor Help"),
-			"",
-			lipgloss.NewStyle().Bold(true).Render("Global Keys:"),
-			"  q, Ctrl+C     - Quit application",
-			"  ?             - Toggle this help",
-			"  Ctrl+N        - Create new tab",
-			"  Ctrl+S        - Save current tab",
-			"  Tab           - Switch between browser and editor",
-			"",
-			lipgloss.NewStyle().Bold(true).Render("Browser Mode:"),
-			"  ↑/k, ↓/j      - Navigate tab list",
-			"  Enter         - Edit selected tab",
-			"  d             - Delete selected tab",
-			"",
-			lipgloss.NewStyle().Bold(true).Render("Editor Mode - Normal:"),
-			"  ↑/k, ↓/j      - Move between strings",
-			"  ←/h, →/l      - Move along string",
-			"  w/b           - Move to next/previous measure",
-			"  g/$           - Move to start/end of measure",
-			"  PgUp/PgDn     - Page up/down scrolling",
-			"  i             - Enter insert mode",
-			"  x             - Delete fret (replace with -)",
-			"  Space         - Play/pause tab",
-			"  m             - Add new measure",
-			"  M             - Remove last measure",
+			lipgloss.NewStyle().Bold(true).Render(title),
 			"",
-			lipgloss.NewStyle().Bold(true).Render("Editor Mode - Insert:"),
-			"  0-9           - Insert fret number (auto-advance)",
-			"  -             - Insert rest (auto-advance)",
-			"  Backspace     - Delete and move back",
-			"  Esc           - Return to normal mode",
-			"  Arrow keys    - Navigate",
+			m.chords.View(),
 			"",
-			lipgloss.NewStyle().Faint(true).Render("Press ? again to close this help"),
+			lipgloss.NewStyle().Faint(true).
+				Render("←/→: Root • ↑/↓: Type • [/]: Voicing • Space: Listen • Enter: Insert • Esc: Cancel"),
 		))
 
-	return helpContent
+	return lipgloss.Place(m.windowSize.Width, m.windowSize.Height,
+		lipgloss.Center, lipgloss.Center, dialog)
 }
 
-func (m Model) renderBrowser() string {
-	title := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("12")).
-		Render("Tuitar - Guitar Tab Browser")
+// helpGroups lists the keys of the active keymaps for the help screen
+func (m Model) helpGroups() []components.KeyGroup {
+	k := m.keys
+	groups := []components.KeyGroup{
+		{Title: "Global Keys", Bindings: []key.Binding{
+			k.Quit, k.Help, k.New, k.Save, k.Export, k.Browser, k.Command, k.Play, k.Follow, k.Normal,
+		}},
+		{Title: "Browser", Bindings: []key.Binding{
+			key.NewBinding(key.WithHelp("↑/k, ↓/j", "navigate the tab list")),
+			k.Enter, k.Import, k.DeleteTab,
+		}},
+		{Title: "Editor", Bindings: []key.Binding{
+			k.Search, k.SearchBack, k.Section, k.Outline, k.Warnings, k.Transpose, k.Capo,
+			k.Chords, k.Fretboard, k.Scale, k.Pane,
+		}},
+	}
+	return append(groups, m.editorKeys.Groups()...)
+}
+
+func (m Model) renderHelp() string {
+	bold := lipgloss.NewStyle().Bold(true)
+	lines := []string{
+		m.theme.Title.Render("Tuitar - Guitar Tab Editor Help"),
+		"",
+	}
+	for _, group := range m.helpGroups() {
+		lines = append(lines, bold.Render(group.Title+":"))
+		for _, b := range group.Bindings {
+			lines = append(lines, fmt.Sprintf("  %-14s - %s", b.Help().Key, b.Help().Desc))
+		}
+		lines = append(lines, "")
+	}
 
-	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("8")).
-		Render("Enter: Edit • Ctrl+N: New • d: Delete • Tab: Editor • ?: Help • Q: Quit")
+	lines = append(lines,
+		bold.Render("Commands:"),
+		"  :w [name]     - Save the tab (under a new name)",
+		"  :q / :wq      - Quit / save and quit",
+		"  :e name       - Edit a saved tab",
+		"  :tempo 140    - Set the tempo",
+		"  :tuning DADGAD - Retune, low to high or a preset (drop d)",
+		"  :capo 2       - Set the capo fret",
+		"  :measure add 3 - Add, insert, append, delete or duplicate",
+		"  :measure remove - Take the last measure off (was M)",
+		"  :transpose +2 - Transpose the tab (or selection)",
+		"  :section name - Start a section at the cursor measure",
+		"  :scale name   - Choose the fretboard scale",
+		"  :export midi out.mid - Export, format from name or extension",
+		"  :import file  - Import a tab",
+		"  :theme light  - Switch color theme (dark, light, high-contrast, mono)",
+		"  :split [name] - Edit a tab (or this one) in a second pane; :vsplit side by side",
+		"  :only         - Close the other pane",
+		"  :12           - Jump to measure 12",
+		"",
+		lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf("Press %s again to close this help", hintKeys(m.keys.Help))),
+	)
+
+	return lipgloss.NewStyle().
+		Padding(1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// hintKeys names the first key of each binding for the hint lines, as in
+// "Ctrl+S" or "+/-"
+func hintKeys(bindings ...key.Binding) string {
+	names := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		keys := b.Keys()
+		if len(keys) == 0 {
+			continue
+		}
+		// Named keys and modifiers are capitalized; a letter only after a
+		// modifier, since q and Q are different keys
+		parts := strings.Split(components.FormatKeys(keys[:1]), "+")
+		for i, part := range parts {
+			if len(part) > 1 || (len(parts) > 1 && part != "") {
+				parts[i] = strings.ToUpper(part[:1]) + part[1:]
+			}
+		}
+		names = append(names, strings.Join(parts, "+"))
+	}
+	return strings.Join(names, "/")
+}
+
+func (m Model) renderBrowser() string {
+	title := m.theme.Title.Render("Tuitar - Guitar Tab Browser")
+
+	help := m.theme.Faint.Render(strings.Join([]string{
+		hintKeys(m.keys.Enter) + ": Edit",
+		hintKeys(m.keys.New) + ": New",
+		hintKeys(m.keys.Import) + ": Import",
+		hintKeys(m.keys.DeleteTab) + ": Delete",
+		hintKeys(m.keys.Browser) + ": Editor",
+		hintKeys(m.keys.Help) + ": Help",
+		hintKeys(m.keys.Quit) + ": Quit",
+	}, " • "))
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		title,
@@ -505,46 +1283,117 @@ func (m Model) renderEditor() string {
 		return "No tab selected"
 	}
 
-	title := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("12")).
-		Render(fmt.Sprintf("Editing: %s", m.state.CurrentTab.Name))
+	title := m.theme.Title.Render(fmt.Sprintf("Editing: %s", m.state.CurrentTab.Name))
+	if capo := m.state.CurrentTab.Capo; capo > 0 {
+		title += m.theme.Form.Render(fmt.Sprintf("  Capo %d", capo))
+	}
 
 	// Show playback status
 	playStatus := ""
 	if m.audioPlayer.IsPlaying() {
-		playStatus = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("10")).
-			Render(" [PLAYING]")
+		playStatus = m.theme.Section.Render(" [PLAYING]")
+		if m.follow {
+			playStatus += m.theme.Faint.Render(" [follow]")
+		}
 	}
 
 	mode := "NORMAL"
-	modeColor := lipgloss.Color("12")
-	if m.state.EditMode == models.EditInsert {
+	modeStyle := m.theme.Title
+	switch m.state.EditMode {
+	case models.EditInsert:
 		mode = "INSERT"
-		modeColor = lipgloss.Color("11")
+		modeStyle = m.theme.Form
+	case models.EditChord:
+		mode = "CHORD"
+		modeStyle = m.theme.Chord
+	case models.EditLyric:
+		mode = "LYRIC"
+		modeStyle = m.theme.Chord
+	case models.EditSelect:
+		mode = "SELECT"
+		modeStyle = m.theme.Analysis
 	}
 
-	modeIndicator := lipgloss.NewStyle().
+	modeIndicator := modeStyle.
 		Bold(true).
-		Foreground(modeColor).
 		Render(fmt.Sprintf("-- %s --", mode)) + playStatus
+	if reg, ok := m.tabEditor.Recording(); ok {
+		modeIndicator += m.theme.Warning.Render(fmt.Sprintf("  recording @%c", reg))
+	}
+	if pending := m.tabEditor.PendingCommand(); pending != "" {
+		modeIndicator += lipgloss.NewStyle().Bold(true).Render("  " + pending)
+	}
+	if m.tabEditor.ShiftScope() == models.ShiftTab {
+		modeIndicator += m.theme.Faint.Render(" [shift: whole tab]")
+	}
 
-	var help string
-	if m.state.EditMode == models.EditInsert {
-		help = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("8")).
-			Render("0-9: Insert fret • -: Rest • Esc: Normal • Arrows: Navigate • Backspace: Delete back")
-	} else {
-		help = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("8")).
-			Render("I: Insert • X: Delete • Space: Play • m: Add Measure • Ctrl+S: Save • Tab: Browser • Arrows: Navigate")
+	keys := m.editorKeys
+	var hints []string
+	switch {
+	case m.tabEditor.PendingForm():
+		hints = []string{"R- [/]: Repeat start/end", "1-4: Ending", "s: Segno", "c: Coda", "t: To Coda", "f: Fine", "j: Jump", "x: Clear"}
+	case m.state.EditMode == models.EditInsert:
+		hints = []string{
+			"0-9: Insert fret",
+			hintKeys(keys.Rest) + ": Rest",
+			hintKeys(keys.Normal) + ": Normal",
+			"Arrows: Navigate",
+			hintKeys(keys.Backspace) + ": Delete back",
+		}
+	case m.state.EditMode == models.EditSelect:
+		hints = []string{
+			"Move: Extend",
+			hintKeys(keys.SemitoneUp, keys.SemitoneDown) + ": Semitone",
+			hintKeys(keys.OctaveUp, keys.OctaveDown) + ": Octave",
+			hintKeys(m.keys.Transpose) + ": Transpose by",
+			hintKeys(keys.StringBelow, keys.StringAbove) + ": Lower/higher string",
+			hintKeys(keys.Yank, keys.Delete) + ": Yank/clear",
+			hintKeys(keys.Normal) + ": Normal",
+		}
+	case m.state.EditMode == models.EditChord || m.state.EditMode == models.EditLyric:
+		hints = []string{"Type text", "Space: Next note", "Enter: Commit", "←/→: Move", "Backspace: Delete", hintKeys(keys.Normal) + ": Normal"}
+	default:
+		hints = []string{
+			hintKeys(keys.Insert) + ": Insert",
+			hintKeys(keys.DeleteFret) + ": Clear",
+			hintKeys(keys.ChordLane, keys.LyricLane) + ": Chords/Lyrics",
+			hintKeys(m.keys.Play) + ": Play",
+			hintKeys(keys.AddMeasure) + ": Add Measure",
+			hintKeys(m.keys.Save) + ": Save",
+			hintKeys(m.keys.Browser) + ": Browser",
+		}
+	}
+	help := m.theme.Faint.Render(strings.Join(hints, " • "))
+
+	editorView := m.renderPanes()
+	if m.showOutline {
+		tab := m.state.CurrentTab
+		outline := m.outline
+		outline.SetSections(tab.Sections, tab.SectionAt(m.tabEditor.GetCursor().Position/models.MeasureLength))
+		editorStyle := lipgloss.NewStyle()
+		if width := m.windowSize.Width - components.OutlineWidth; width > 0 {
+			editorStyle = editorStyle.Width(width)
+		}
+		editorView = lipgloss.JoinHorizontal(lipgloss.Top, editorStyle.Render(editorView), outline.View())
+	}
+	if m.showWarns {
+		tab := m.state.CurrentTab
+		warnings := m.warnings
+		warnings.SetWarnings(tab.Playability(warnings.MaxStretch()), tab.Tuning)
+		editorStyle := lipgloss.NewStyle()
+		if width := m.windowSize.Width - components.OutlineWidth; width > 0 {
+			editorStyle = editorStyle.Width(width)
+		}
+		editorView = lipgloss.JoinHorizontal(lipgloss.Top, editorStyle.Render(editorView), warnings.View())
+	}
+	if m.showNeck {
+		editorView = lipgloss.JoinVertical(lipgloss.Left, editorView, m.renderFretboard())
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		title,
 		"",
-		m.tabEditor.View(),
+		editorView,
 		"",
 		modeIndicator,
 		help,
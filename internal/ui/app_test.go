@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cod-e-Codes/tuitar/internal/ui/components"
+)
+
+// enterInput types value into the dialog of an input mode and presses Enter
+func enterInput(m Model, mode inputMode, value string) Model {
+	m.inputMode = mode
+	m.textInput.SetValue(value)
+	updated, _ := m.updateInput(tea.KeyMsg{Type: tea.KeyEnter})
+	return updated.(Model)
+}
+
+func TestScaleDialog(t *testing.T) {
+	m := newTestModel("Lead")
+	m.fretboard = components.NewFretboard()
+	m.runCommand("e Lead")
+
+	m = enterInput(m, inputModeScale, "A minor")
+	if !m.scaleChosen || m.scale.Name() != "A minor" {
+		t.Fatalf("Expected A minor chosen, got %q (chosen %v)", m.scale.Name(), m.scaleChosen)
+	}
+	if m.inputMode != inputModeNone {
+		t.Error("Expected the dialog closed")
+	}
+
+	// An empty scale goes back to the detected key
+	m = enterInput(m, inputModeScale, "")
+	if m.scaleChosen {
+		t.Errorf("Expected an empty scale to clear %q", m.scale.Name())
+	}
+	if m.inputMode != inputModeNone {
+		t.Error("Expected the dialog closed")
+	}
+}
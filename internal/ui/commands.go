@@ -0,0 +1,618 @@
+// internal/ui/commands.go
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+	"github.com/Cod-e-Codes/tuitar/internal/ui/components"
+)
+
+// exCommand is a command typed on the : line. Commands may be shortened
+// to any prefix that is not shared with another command.
+type exCommand struct {
+	name    string
+	usage   string
+	needTab bool // The command acts on the tab open in the editor
+	tabName bool // The argument is the name of a saved tab, which may hold spaces
+	run     func(m *Model, args []string) (tea.Cmd, error)
+}
+
+// exCommands lists the : commands in the order shown by :help
+var exCommands = []exCommand{
+	{name: "w", usage: "w [name]", needTab: true, run: (*Model).cmdWrite},
+	{name: "q", usage: "q", run: (*Model).cmdQuit},
+	{name: "wq", usage: "wq", needTab: true, run: (*Model).cmdWriteQuit},
+	{name: "e", usage: "e <tab name>", tabName: true, run: (*Model).cmdEdit},
+	{name: "tempo", usage: "tempo <bpm>", needTab: true, run: (*Model).cmdTempo},
+	{name: "tuning", usage: "tuning <notes low to high | preset>", needTab: true, run: (*Model).cmdTuning},
+	{name: "capo", usage: "capo <fret>", needTab: true, run: (*Model).cmdCapo},
+	{name: "measure", usage: "measure add|remove|insert|append|delete|duplicate [count]", needTab: true, run: (*Model).cmdMeasure},
+	{name: "transpose", usage: "transpose <+/-semitones>", needTab: true, run: (*Model).cmdTranspose},
+	{name: "section", usage: "section [name]", needTab: true, run: (*Model).cmdSection},
+	{name: "scale", usage: "scale [name]", needTab: true, run: (*Model).cmdScale},
+	{name: "nohlsearch", usage: "nohlsearch", needTab: true, run: (*Model).cmdNoHighlight},
+	{name: "marks", usage: "marks", needTab: true, run: (*Model).cmdMarks},
+	{name: "split", usage: "split [tab name]", needTab: true, tabName: true, run: (*Model).cmdSplit},
+	{name: "vsplit", usage: "vsplit [tab name]", needTab: true, tabName: true, run: (*Model).cmdVSplit},
+	{name: "only", usage: "only", needTab: true, run: (*Model).cmdOnly},
+	{name: "theme", usage: "theme [dark|light|high-contrast|mono]", run: (*Model).cmdTheme},
+	{name: "export", usage: "export [format] [file]", needTab: true, run: (*Model).cmdExport},
+	{name: "import", usage: "import <file>", run: (*Model).cmdImport},
+	{name: "help", usage: "help", run: (*Model).cmdHelp},
+}
+
+// exportFormats maps the format names accepted by :export to the file
+// extension that selects the writer
+var exportFormats = map[string]string{
+	"midi":     ".mid",
+	"musicxml": ".musicxml",
+	"lilypond": ".ly",
+	"alphatex": ".atex",
+	"chordpro": ".cho",
+	"text":     ".txt",
+	"svg":      ".svg",
+	"html":     ".html",
+}
+
+var measureOps = []string{"add", "append", "delete", "duplicate", "insert", "remove"}
+
+// maxHistory is the number of command lines kept for Up/Down recall
+const maxHistory = 50
+
+// openCommandLine starts typing a : command
+func (m *Model) openCommandLine() {
+	m.inputMode = inputModeCommand
+	m.textInput.Prompt = ":"
+	m.textInput.Placeholder = ""
+	m.textInput.SetValue("")
+	m.textInput.Focus()
+	m.historyIdx = len(m.history)
+	m.completions = nil
+}
+
+// openSearch starts typing a search on the command line, / searching
+// forward and ? backward
+func (m *Model) openSearch(backward bool) {
+	m.inputMode = inputModeSearch
+	m.textInput.Prompt = "/"
+	if backward {
+		m.textInput.Prompt = "?"
+	}
+	m.textInput.Placeholder = "fret, note or lick, e.g. 7, C#, 5-7-8"
+	m.textInput.SetValue("")
+	m.textInput.Focus()
+	m.historyIdx = len(m.searchHistory)
+	m.completions = nil
+}
+
+// lineHistory returns the history of the line being typed: searches and
+// commands are kept apart
+func (m *Model) lineHistory() *[]string {
+	if m.inputMode == inputModeSearch {
+		return &m.searchHistory
+	}
+	return &m.history
+}
+
+// closeCommandLine goes back to the view the command line was opened from
+func (m *Model) closeCommandLine() {
+	m.inputMode = inputModeNone
+	m.textInput.Blur()
+	m.textInput.SetValue("")
+	m.textInput.Prompt = "> "
+	m.textInput.Placeholder = "Enter tab name..."
+	m.completions = nil
+}
+
+func (m Model) updateCommandLine(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.closeCommandLine()
+		return m, nil
+
+	case tea.KeyBackspace:
+		// Backspace on an empty line leaves it, as in vim
+		if m.textInput.Value() == "" {
+			m.closeCommandLine()
+			return m, nil
+		}
+
+	case tea.KeyEnter:
+		line := strings.TrimSpace(m.textInput.Value())
+		history := m.lineHistory()
+		search := m.inputMode == inputModeSearch
+		backward := m.textInput.Prompt == "?"
+		m.closeCommandLine()
+		if line == "" && search && len(*history) > 0 {
+			// An empty search repeats the last one
+			line = (*history)[len(*history)-1]
+		}
+		if line == "" {
+			return m, nil
+		}
+		if len(*history) == 0 || (*history)[len(*history)-1] != line {
+			*history = append(*history, line)
+			if len(*history) > maxHistory {
+				*history = (*history)[1:]
+			}
+		}
+		if search {
+			m.tabEditor.Search(line, backward)
+			m.statusBar.SetStatus(m.tabEditor.Status())
+			return m, nil
+		}
+		cmd := m.runCommand(line)
+		return m, cmd
+
+	case tea.KeyTab, tea.KeyShiftTab:
+		if m.inputMode == inputModeCommand {
+			m.complete(msg.Type == tea.KeyShiftTab)
+		}
+		return m, nil
+
+	case tea.KeyUp:
+		history := *m.lineHistory()
+		if m.historyIdx > 0 {
+			m.historyIdx--
+			m.textInput.SetValue(history[m.historyIdx])
+			m.textInput.CursorEnd()
+		}
+		m.completions = nil
+		return m, nil
+
+	case tea.KeyDown:
+		history := *m.lineHistory()
+		if m.historyIdx < len(history) {
+			m.historyIdx++
+			value := ""
+			if m.historyIdx < len(history) {
+				value = history[m.historyIdx]
+			}
+			m.textInput.SetValue(value)
+			m.textInput.CursorEnd()
+		}
+		m.completions = nil
+		return m, nil
+	}
+
+	m.completions = nil
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// complete fills in the word under the cursor. The first Tab extends it to
+// the longest prefix the candidates share; further presses cycle through
+// them, backwards with Shift+Tab.
+func (m *Model) complete(backwards bool) {
+	if len(m.completions) > 0 {
+		step := 1
+		if backwards {
+			step = len(m.completions) - 1
+		}
+		m.completionIdx = (m.completionIdx + step) % len(m.completions)
+		m.textInput.SetValue(m.completionBase + m.completions[m.completionIdx])
+		m.textInput.CursorEnd()
+		return
+	}
+
+	line := m.textInput.Value()
+	base, word := "", line
+	if i := strings.LastIndex(line, " "); i >= 0 {
+		base, word = line[:i+1], line[i+1:]
+	}
+	// Tab names may have spaces, so :e and :split complete the rest of the line
+	if name, rest, ok := strings.Cut(line, " "); ok {
+		if c, found := lookupCommand(name); found && c.tabName {
+			base, word = name+" ", strings.TrimLeft(rest, " ")
+		}
+	}
+
+	candidates := m.candidates(strings.Fields(base), word)
+	if len(candidates) == 0 {
+		return
+	}
+
+	prefix := candidates[0]
+	for _, c := range candidates[1:] {
+		for !strings.HasPrefix(strings.ToLower(c), strings.ToLower(prefix)) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(candidates) == 1 {
+		prefix += " "
+	}
+	if len(prefix) > len(word) || len(candidates) == 1 {
+		m.textInput.SetValue(base + prefix)
+		m.textInput.CursorEnd()
+		return
+	}
+
+	// Nothing more in common: cycle through the candidates from the next Tab
+	m.completions = candidates
+	m.completionBase = base
+	m.completionIdx = -1
+	if backwards {
+		m.completionIdx = 0
+	}
+	m.complete(backwards)
+}
+
+// candidates returns the completions of word after the words already typed
+func (m Model) candidates(words []string, word string) []string {
+	var options []string
+	if len(words) == 0 {
+		for _, c := range exCommands {
+			options = append(options, c.name)
+		}
+	} else if c, ok := lookupCommand(words[0]); ok && len(words) == 1 {
+		switch {
+		case c.tabName:
+			for _, tab := range m.tabs {
+				options = append(options, tab.Name)
+			}
+		case c.name == "measure":
+			options = measureOps
+		case c.name == "tuning":
+			for name := range models.TuningPresets {
+				options = append(options, name)
+			}
+		case c.name == "export":
+			for name := range exportFormats {
+				options = append(options, name)
+			}
+		case c.name == "theme":
+			options = components.ThemeNames
+		}
+	}
+	sort.Strings(options)
+
+	var matches []string
+	for _, option := range options {
+		if strings.HasPrefix(strings.ToLower(option), strings.ToLower(word)) && option != "" {
+			matches = append(matches, option)
+		}
+	}
+	return matches
+}
+
+// lookupCommand finds a command by its name or an unambiguous prefix
+func lookupCommand(name string) (exCommand, bool) {
+	var found []exCommand
+	for _, c := range exCommands {
+		if c.name == name {
+			return c, true
+		}
+		if strings.HasPrefix(c.name, name) {
+			found = append(found, c)
+		}
+	}
+	if len(found) == 1 {
+		return found[0], true
+	}
+	return exCommand{}, false
+}
+
+// errUsage makes runCommand show how the command is used
+var errUsage = errors.New("usage")
+
+// runCommand parses and runs a command line. A bare number jumps to that
+// measure.
+func (m *Model) runCommand(line string) tea.Cmd {
+	words := strings.Fields(line)
+	editing := m.state.ViewMode == models.ViewEditor && m.state.CurrentTab != nil
+
+	if measure, err := strconv.Atoi(words[0]); err == nil && len(words) == 1 {
+		if !editing {
+			m.statusBar.SetStatus("No tab open")
+			return nil
+		}
+		if measure < 1 || measure > m.state.CurrentTab.GetMeasureCount() {
+			m.statusBar.SetStatus(fmt.Sprintf("No measure %d", measure))
+			return nil
+		}
+		m.tabEditor.JumpToMeasure(measure - 1)
+		return nil
+	}
+
+	c, ok := lookupCommand(words[0])
+	if !ok {
+		m.statusBar.SetStatus("Not a command: " + words[0])
+		return nil
+	}
+	if c.needTab && !editing {
+		m.statusBar.SetStatus("No tab open")
+		return nil
+	}
+
+	cmd, err := c.run(m, words[1:])
+	switch {
+	case errors.Is(err, errUsage):
+		m.statusBar.SetStatus("Usage: :" + c.usage)
+	case err != nil:
+		msg := err.Error()
+		m.statusBar.SetStatus(strings.ToUpper(msg[:1]) + msg[1:])
+	}
+	return cmd
+}
+
+func (m *Model) cmdWrite(args []string) (tea.Cmd, error) {
+	if len(args) > 0 {
+		m.state.CurrentTab.Name = strings.Join(args, " ")
+	}
+	m.saveCurrentTab()
+	return nil, nil
+}
+
+func (m *Model) cmdQuit([]string) (tea.Cmd, error) {
+	if m.audioPlayer.IsPlaying() {
+		m.audioPlayer.Stop()
+	}
+	return tea.Quit, nil
+}
+
+func (m *Model) cmdWriteQuit(args []string) (tea.Cmd, error) {
+	if err := m.saveCurrentTab(); err != nil {
+		return nil, nil // The save already reported the error
+	}
+	return m.cmdQuit(args)
+}
+
+// cmdEdit opens a saved tab by its name, or by the start of its name when
+// only one tab has it
+func (m *Model) cmdEdit(args []string) (tea.Cmd, error) {
+	if len(args) == 0 {
+		return nil, errUsage
+	}
+	match, err := m.findTab(strings.Join(args, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	tab := m.savedTab(match, m.other.GetTab())
+	m.openEditor(tab)
+	m.tabBrowser.SetCursor(match)
+	m.statusBar.SetStatus("Editing: " + tab.Name)
+	return nil, nil
+}
+
+// findTab returns the index of the saved tab with a name, or the only
+// one whose name starts with it
+func (m *Model) findTab(name string) (int, error) {
+	match := -1
+	for i, tab := range m.tabs {
+		if strings.EqualFold(tab.Name, name) {
+			return i, nil
+		}
+		if strings.HasPrefix(strings.ToLower(tab.Name), strings.ToLower(name)) {
+			if match != -1 {
+				return -1, fmt.Errorf("more than one tab starts with %s", name)
+			}
+			match = i
+		}
+	}
+	if match == -1 {
+		return -1, fmt.Errorf("no tab named %s", name)
+	}
+	return match, nil
+}
+
+// cmdSplit opens a second pane below, on a saved tab or on the tab being
+// edited to work on two places of it
+func (m *Model) cmdSplit(args []string) (tea.Cmd, error) {
+	return m.splitOn(splitStacked, args)
+}
+
+// cmdVSplit opens a second pane to the right
+func (m *Model) cmdVSplit(args []string) (tea.Cmd, error) {
+	return m.splitOn(splitSide, args)
+}
+
+func (m *Model) splitOn(layout splitLayout, args []string) (tea.Cmd, error) {
+	if m.split != splitNone {
+		return nil, errors.New("there are two panes already: :only closes the other one")
+	}
+	tab := m.state.CurrentTab
+	if len(args) > 0 {
+		match, err := m.findTab(strings.Join(args, " "))
+		if err != nil {
+			return nil, err
+		}
+		tab = m.savedTab(match, m.state.CurrentTab)
+	}
+	m.openSplit(layout, tab)
+	m.statusBar.SetStatus(fmt.Sprintf("Editing: %s (%s switches panes)", tab.Name, hintKeys(m.keys.Pane)))
+	return nil, nil
+}
+
+func (m *Model) cmdOnly([]string) (tea.Cmd, error) {
+	if m.split == splitNone {
+		return nil, errors.New("there is only one pane")
+	}
+	m.closeSplit()
+	return nil, nil
+}
+
+func (m *Model) cmdTempo(args []string) (tea.Cmd, error) {
+	if len(args) != 1 {
+		return nil, errUsage
+	}
+	tempo, err := strconv.Atoi(args[0])
+	if err != nil || tempo < 20 || tempo > 400 {
+		return nil, errors.New("tempo must be from 20 to 400 BPM")
+	}
+	m.state.CurrentTab.Tempo = tempo
+	m.state.CurrentTab.UpdatedAt = time.Now()
+	m.statusBar.SetStatus(fmt.Sprintf("Tempo set to %d BPM", tempo))
+	return nil, nil
+}
+
+// cmdTuning retunes the strings; the frets stay, so the notes change pitch
+func (m *Model) cmdTuning(args []string) (tea.Cmd, error) {
+	if len(args) == 0 {
+		return nil, errUsage
+	}
+	tuning, ok := models.ParseTuning(strings.Join(args, " "))
+	if !ok {
+		return nil, fmt.Errorf("not a tuning of six strings: %s", strings.Join(args, " "))
+	}
+	m.state.CurrentTab.Tuning = tuning
+	m.state.CurrentTab.UpdatedAt = time.Now()
+	m.statusBar.SetStatus(fmt.Sprintf("Tuning set to %s %s %s %s %s %s",
+		tuning[5], tuning[4], tuning[3], tuning[2], tuning[1], tuning[0]))
+	return nil, nil
+}
+
+func (m *Model) cmdCapo(args []string) (tea.Cmd, error) {
+	if len(args) != 1 {
+		return nil, errUsage
+	}
+	m.setCapo(args[0], false)
+	return nil, nil
+}
+
+func (m *Model) cmdMeasure(args []string) (tea.Cmd, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return nil, errUsage
+	}
+	count := 1
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return nil, errUsage
+		}
+		count = n
+	}
+	m.tabEditor.EditMeasures(args[0], count)
+	m.statusBar.SetStatus(m.tabEditor.Status())
+	m.state.CurrentTab = m.tabEditor.GetTab()
+	return nil, nil
+}
+
+func (m *Model) cmdTranspose(args []string) (tea.Cmd, error) {
+	if len(args) != 1 {
+		return nil, errUsage
+	}
+	semitones, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("not a number of semitones: %s", args[0])
+	}
+	m.tabEditor.Transpose(semitones)
+	m.statusBar.SetStatus(m.tabEditor.Status())
+	return nil, nil
+}
+
+func (m *Model) cmdSection(args []string) (tea.Cmd, error) {
+	m.setSection(strings.Join(args, " "))
+	return nil, nil
+}
+
+func (m *Model) cmdScale(args []string) (tea.Cmd, error) {
+	m.setScale(strings.Join(args, " "))
+	return nil, nil
+}
+
+// cmdExport writes the tab to a file. The format may be named before the
+// file; a file without an extension gets the format's, and without a file
+// the tab name is used.
+func (m *Model) cmdExport(args []string) (tea.Cmd, error) {
+	ext := ".musicxml"
+	if len(args) > 0 {
+		if formatExt, ok := exportFormats[strings.ToLower(args[0])]; ok {
+			ext = formatExt
+			args = args[1:]
+		}
+	}
+	if len(args) > 1 {
+		return nil, errUsage
+	}
+
+	path := exportFileName(m.state.CurrentTab.Name) + ext
+	if len(args) == 1 {
+		path = args[0]
+		if filepath.Ext(path) == "" {
+			path += ext
+		}
+	}
+	m.exportCurrentTab(path)
+	return nil, nil
+}
+
+func (m *Model) cmdImport(args []string) (tea.Cmd, error) {
+	if len(args) != 1 {
+		return nil, errUsage
+	}
+	m.importTab(args[0])
+	return nil, nil
+}
+
+// cmdNoHighlight hides the matches of the last search until n or N
+func (m *Model) cmdNoHighlight([]string) (tea.Cmd, error) {
+	m.tabEditor.ClearSearch()
+	return nil, nil
+}
+
+// cmdTheme switches to a built-in theme, or names the current one
+func (m *Model) cmdTheme(args []string) (tea.Cmd, error) {
+	switch len(args) {
+	case 0:
+		m.statusBar.SetStatus("Theme: " + m.themeName)
+		return nil, nil
+	case 1:
+		if err := m.setTheme(strings.ToLower(args[0])); err != nil {
+			return nil, err
+		}
+		m.statusBar.SetStatus("Theme: " + m.themeName)
+		return nil, nil
+	}
+	return nil, errUsage
+}
+
+// cmdMarks lists the marks of the tab with their measure and string
+func (m *Model) cmdMarks([]string) (tea.Cmd, error) {
+	tab := m.state.CurrentTab
+	if len(tab.Marks) == 0 {
+		return nil, errors.New("no marks set")
+	}
+	var marks []string
+	for _, mark := range tab.Marks {
+		marks = append(marks, fmt.Sprintf("%s: m%d %s", mark.Name,
+			mark.Position/models.MeasureLength+1, tab.Tuning[mark.String]))
+	}
+	m.statusBar.SetStatus("Marks  " + strings.Join(marks, "  "))
+	return nil, nil
+}
+
+func (m *Model) cmdHelp([]string) (tea.Cmd, error) {
+	m.showHelp = true
+	return nil, nil
+}
+
+// renderCommandLine draws the : or search line in place of the status bar, with the
+// completions being cycled through above it
+func (m Model) renderCommandLine() string {
+	line := m.textInput.View()
+	if len(m.completions) == 0 {
+		return line
+	}
+
+	var items []string
+	for i, c := range m.completions {
+		style := m.theme.Faint
+		if i == m.completionIdx {
+			style = m.theme.Selected
+		}
+		items = append(items, style.Render(c))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, strings.Join(items, "  "), line)
+}
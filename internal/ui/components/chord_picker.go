@@ -0,0 +1,200 @@
+// internal/ui/components/chord_picker.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+	"github.com/Cod-e-Codes/tuitar/internal/theory"
+)
+
+// diagramFrets is the number of frets drawn in a chord diagram
+const diagramFrets = 4
+
+// ChordPickerModel lets the user choose a chord from the library and one of
+// its voicings for the tab's tuning
+type ChordPickerModel struct {
+	tuning   [6]int    // MIDI note of each string at fret 0 of the tab
+	labels   [6]string // String names, index 0 = highest string
+	root     int
+	quality  int
+	voicings []theory.Voicing
+	voicing  int
+	theme    Theme
+}
+
+func NewChordPicker() ChordPickerModel {
+	return ChordPickerModel{theme: DarkTheme()}
+}
+
+func (m *ChordPickerModel) SetTheme(theme Theme) {
+	m.theme = theme
+}
+
+// SetTab generates voicings for the tab's tuning and capo. The chord that
+// was last chosen stays selected.
+func (m *ChordPickerModel) SetTab(tab *models.Tab) {
+	m.tuning = tab.CapoStringMIDI()
+	m.labels = tab.Tuning
+	m.generate()
+}
+
+// generate rebuilds the voicing list for the selected chord
+func (m *ChordPickerModel) generate() {
+	m.voicings = theory.Voicings(m.Chord(), m.tuning)
+	m.voicing = 0
+}
+
+// Chord returns the chord currently selected
+func (m ChordPickerModel) Chord() theory.Chord {
+	return theory.Chord{Root: m.root, Quality: theory.Qualities[m.quality]}
+}
+
+// Voicing returns the shape currently selected, if the chord has any
+func (m ChordPickerModel) Voicing() (theory.Voicing, bool) {
+	if m.voicing >= len(m.voicings) {
+		return theory.Voicing{}, false
+	}
+	return m.voicings[m.voicing], true
+}
+
+// MIDINotes returns the pitches of the selected voicing in string order,
+// for previewing it
+func (m ChordPickerModel) MIDINotes() []int {
+	voicing, ok := m.Voicing()
+	if !ok {
+		return nil
+	}
+	var notes []int
+	for str, fret := range voicing.Frets {
+		if fret != theory.Muted {
+			notes = append(notes, m.tuning[str]+fret)
+		}
+	}
+	return notes
+}
+
+func (m ChordPickerModel) Update(msg tea.Msg) (ChordPickerModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "h", "left":
+			m.root = (m.root + 11) % 12
+			m.generate()
+		case "l", "right":
+			m.root = (m.root + 1) % 12
+			m.generate()
+		case "k", "up":
+			if m.quality > 0 {
+				m.quality--
+				m.generate()
+			}
+		case "j", "down":
+			if m.quality < len(theory.Qualities)-1 {
+				m.quality++
+				m.generate()
+			}
+		case "]", "n":
+			if m.voicing < len(m.voicings)-1 {
+				m.voicing++
+			}
+		case "[", "p":
+			if m.voicing > 0 {
+				m.voicing--
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m ChordPickerModel) View() string {
+	chord := m.Chord()
+
+	var qualities []string
+	for i, quality := range theory.Qualities {
+		item := fmt.Sprintf("%-6s %s", models.NoteNames[m.root]+quality.Suffix, quality.Name)
+		style := lipgloss.NewStyle()
+		if i == m.quality {
+			style = m.theme.Selected
+		}
+		qualities = append(qualities, style.Render(item))
+	}
+
+	title := m.theme.Chord.Render(chord.Name())
+	preview := []string{title}
+	if voicing, ok := m.Voicing(); ok {
+		preview = append(preview,
+			m.theme.Faint.
+				Render(fmt.Sprintf("Voicing %d of %d", m.voicing+1, len(m.voicings))),
+			"",
+			m.renderDiagram(voicing))
+	} else {
+		preview = append(preview, "",
+			m.theme.Faint.
+				Render("No playable voicing\nin this tuning."))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().PaddingRight(4).Render(strings.Join(qualities, "\n")),
+		strings.Join(preview, "\n"))
+}
+
+// renderDiagram draws a chord box with the lowest string on the left, the
+// usual orientation of chord charts
+func (m ChordPickerModel) renderDiagram(voicing theory.Voicing) string {
+	maxFret := 0
+	for _, fret := range voicing.Frets {
+		if fret > maxFret {
+			maxFret = fret
+		}
+	}
+	base := 1
+	if maxFret > diagramFrets {
+		base = voicing.BaseFret()
+	}
+
+	var names, markers, frets []string
+	for str := 5; str >= 0; str-- {
+		names = append(names, fmt.Sprintf("%-2s", m.labels[str]))
+		switch fret := voicing.Frets[str]; fret {
+		case theory.Muted:
+			markers = append(markers, "x ")
+			frets = append(frets, "x ")
+		case 0:
+			markers = append(markers, "o ")
+			frets = append(frets, "0 ")
+		default:
+			markers = append(markers, "  ")
+			frets = append(frets, fmt.Sprintf("%-2d", fret))
+		}
+	}
+
+	dot := m.theme.Section.Render("●")
+	faint := m.theme.Faint
+
+	nut := strings.Repeat("─", 11)
+	if base == 1 {
+		nut = strings.Repeat("═", 11)
+	}
+	lines := []string{
+		"    " + strings.Join(names, ""),
+		"    " + strings.Join(markers, ""),
+		"    " + nut,
+	}
+	for fret := base; fret < base+diagramFrets; fret++ {
+		var cells []string
+		for str := 5; str >= 0; str-- {
+			if voicing.Frets[str] == fret {
+				cells = append(cells, dot)
+			} else {
+				cells = append(cells, "│")
+			}
+		}
+		lines = append(lines, faint.Render(fmt.Sprintf("%2d  ", fret))+strings.Join(cells, " "))
+	}
+	lines = append(lines, "", "    "+strings.Join(frets, ""))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,279 @@
+// internal/ui/components/fretboard.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+	"github.com/Cod-e-Codes/tuitar/internal/theory"
+)
+
+// FretboardHeight is the height of the fretboard panel, including its border
+const FretboardHeight = 11
+
+// fretWidth is the number of characters drawn for each fret
+const fretWidth = 4
+
+// inlayFrets are the frets with position markers on a guitar neck
+var inlayFrets = map[int]bool{3: true, 5: true, 7: true, 9: true, 12: true, 15: true, 17: true, 19: true, 21: true, 24: true}
+
+// FretboardModel draws the neck of the tab's instrument with a scale laid
+// over it and the notes under the editor cursor and playback marked. When
+// focused, a cursor on the neck picks the string and fret of new notes.
+type FretboardModel struct {
+	tuning   [6]int    // MIDI note of each string at fret 0 of the tab
+	labels   [6]string // String names, index 0 = highest string
+	scale    theory.Scale
+	hasScale bool
+	detected bool // The scale was estimated from the tab rather than chosen
+	cursor   []models.Note
+	playing  []models.Note
+	width    int
+	focused  bool
+	str      int // Fretboard cursor string
+	fret     int // Fretboard cursor fret
+	first    int // First fret past the nut shown when the neck does not fit
+	theme    Theme
+}
+
+func NewFretboard() FretboardModel {
+	return FretboardModel{first: 1, theme: DarkTheme()}
+}
+
+func (m *FretboardModel) SetTheme(theme Theme) {
+	m.theme = theme
+}
+
+// SetTab follows the tuning and capo of the tab
+func (m *FretboardModel) SetTab(tab *models.Tab) {
+	m.tuning = tab.CapoStringMIDI()
+	m.labels = tab.Tuning
+}
+
+// SetScale sets the scale laid over the neck; detected marks it as an
+// estimate in the title
+func (m *FretboardModel) SetScale(scale theory.Scale, detected bool) {
+	m.scale = scale
+	m.hasScale = true
+	m.detected = detected
+}
+
+// ClearScale removes the scale overlay
+func (m *FretboardModel) ClearScale() {
+	m.hasScale = false
+}
+
+// SetNotes marks the notes of the editor cursor column and the notes
+// sounding during playback
+func (m *FretboardModel) SetNotes(cursor, playing []models.Note) {
+	m.cursor = cursor
+	m.playing = playing
+}
+
+func (m *FretboardModel) SetSize(width int) {
+	m.width = width
+	m.scrollTo(m.fret)
+}
+
+// Focus puts the fretboard cursor on a string and fret
+func (m *FretboardModel) Focus(str, fret int) {
+	m.focused = true
+	m.str = str
+	m.fret = fret
+	m.scrollTo(fret)
+}
+
+func (m *FretboardModel) Blur() {
+	m.focused = false
+}
+
+func (m FretboardModel) Focused() bool {
+	return m.focused
+}
+
+// Cursor returns the string and fret under the fretboard cursor
+func (m FretboardModel) Cursor() (str, fret int) {
+	return m.str, m.fret
+}
+
+// scrollTo shifts the visible frets so the given fret is shown
+func (m *FretboardModel) scrollTo(fret int) {
+	frets := m.frets()
+	if m.first < 1 {
+		m.first = 1
+	}
+	if fret >= m.first+frets {
+		m.first = fret - frets + 1
+	}
+	if fret > 0 && fret < m.first {
+		m.first = fret
+	}
+	if last := models.MaxFret - frets + 1; m.first > last {
+		m.first = max(last, 1)
+	}
+}
+
+func (m FretboardModel) Update(msg tea.Msg) (FretboardModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "h", "left":
+			if m.fret > 0 {
+				m.fret--
+			}
+		case "l", "right":
+			if m.fret < models.MaxFret {
+				m.fret++
+			}
+		case "k", "up":
+			if m.str > 0 {
+				m.str--
+			}
+		case "j", "down":
+			if m.str < 5 {
+				m.str++
+			}
+		case "0", "home":
+			m.fret = 0
+		case "$", "end":
+			m.fret = models.MaxFret
+		}
+		m.scrollTo(m.fret)
+	}
+	return m, nil
+}
+
+// frets returns how many frets past the nut fit in the panel at once
+func (m FretboardModel) frets() int {
+	width := m.width
+	if width == 0 {
+		width = 120
+	}
+	frets := (width - 4 - 5) / fretWidth // Border, padding, label and open string
+	if frets > models.MaxFret {
+		frets = models.MaxFret
+	}
+	if frets < 5 {
+		frets = 5
+	}
+	return frets
+}
+
+// marked reports whether a note on the string and fret is in the list
+func marked(notes []models.Note, str, fret int) bool {
+	for _, note := range notes {
+		if note.String == str && note.Fret == fret {
+			return true
+		}
+	}
+	return false
+}
+
+func (m FretboardModel) View() string {
+	frets := m.frets()
+
+	// Without focus, the neck scrolls to keep the marked notes in view
+	if !m.focused {
+		for _, note := range append(append([]models.Note{}, m.cursor...), m.playing...) {
+			m.scrollTo(note.Fret)
+		}
+	}
+	last := m.first + frets - 1
+
+	title := "No notes to detect a key from"
+	if m.hasScale {
+		title = "Scale: " + m.scale.Name()
+		if m.detected {
+			title += " (detected)"
+		}
+	}
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(title)}
+
+	wire := m.theme.Faint
+	rootStyle := m.theme.Form
+	scaleStyle := m.theme.Scale
+	cursorStyle := m.theme.Selected
+	playingStyle := m.theme.Playing
+	focusStyle := m.theme.Insert
+
+	// cell draws the mark of one fret: the tonic, another scale tone, or a
+	// note outside the scale that is under the cursor or sounding
+	cell := func(str, fret int) string {
+		degree := -1
+		if m.hasScale {
+			degree = m.scale.Degree((m.tuning[str] + fret) % 12)
+		}
+		glyph, style := "", wire
+		switch {
+		case degree == 0:
+			glyph, style = "◆", rootStyle
+		case degree > 0:
+			glyph, style = "●", scaleStyle
+		}
+
+		switch {
+		case m.focused && str == m.str && fret == m.fret:
+			if glyph == "" {
+				glyph = "+"
+			}
+			style = focusStyle
+		case marked(m.playing, str, fret):
+			if glyph == "" {
+				glyph = "○"
+			}
+			style = playingStyle
+		case marked(m.cursor, str, fret):
+			if glyph == "" {
+				glyph = "○"
+			}
+			style = cursorStyle
+		}
+
+		if glyph == "" {
+			return wire.Render("─")
+		}
+		return style.Render(glyph)
+	}
+
+	for str := 0; str < 6; str++ {
+		line := m.theme.Label.Render(fmt.Sprintf("%-2s ", m.labels[str]))
+		nut := "‖"
+		if m.first > 1 {
+			nut = "┊" // Frets are scrolled out of view after the open string
+		}
+		line += cell(str, 0) + wire.Render(nut)
+		for fret := m.first; fret <= last; fret++ {
+			line += wire.Render("─") + cell(str, fret) + wire.Render("─│")
+		}
+		lines = append(lines, line)
+	}
+
+	// Fret numbers under the inlays, as on the side of a neck
+	numbers := "    "
+	for fret := m.first; fret <= last; fret++ {
+		label := strings.Repeat(" ", fretWidth)
+		if inlayFrets[fret] || (fret == m.first && fret > 1) {
+			label = fmt.Sprintf("%3d ", fret)
+		}
+		numbers += label
+	}
+	lines = append(lines, wire.Render(numbers))
+
+	legend := rootStyle.Render("◆") + " tonic  " + scaleStyle.Render("●") + " scale  " +
+		cursorStyle.Render("○") + " cursor  " + playingStyle.Render("○") + " playing"
+	if m.focused {
+		str, fret := m.Cursor()
+		legend = fmt.Sprintf("%s string fret %d (%s) • Enter: Place • x: Remove • Space/Bksp: Column • Esc: Back",
+			m.labels[str], fret, models.NoteNames[(m.tuning[str]+fret)%12])
+	}
+	lines = append(lines, legend)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.border(m.focused)).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
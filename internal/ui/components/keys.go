@@ -0,0 +1,313 @@
+// internal/ui/components/keys.go
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EditorKeyMap holds the bindings of the tab editor. Digits, the letters
+// named after m, q, @ and ', the key after R and the text typed in the
+// chord and lyric lanes are not bindings: they are what the command acts on.
+type EditorKeyMap struct {
+	// Movement; these are the motions operators act over
+	Left         key.Binding
+	Right        key.Binding
+	Up           key.Binding
+	Down         key.Binding
+	NextMeasure  key.Binding
+	PrevMeasure  key.Binding
+	MeasureStart key.Binding
+	MeasureEnd   key.Binding
+	StringStart  key.Binding
+	StringEnd    key.Binding
+	NextMatch    key.Binding
+	PrevMatch    key.Binding
+
+	// Jumps and scrolling
+	PageUp      key.Binding
+	PageDown    key.Binding
+	NextSection key.Binding
+	PrevSection key.Binding
+	JumpOlder   key.Binding
+	JumpNewer   key.Binding
+	SetMark     key.Binding
+	GoToMark    key.Binding
+
+	// Modes
+	Insert    key.Binding
+	Normal    key.Binding
+	Select    key.Binding
+	ChordLane key.Binding
+	LyricLane key.Binding
+	Form      key.Binding
+
+	// Editing
+	DeleteFret  key.Binding
+	Delete      key.Binding
+	Yank        key.Binding
+	Change      key.Binding
+	Paste       key.Binding
+	PasteBefore key.Binding
+	Repeat      key.Binding
+	Record      key.Binding
+	PlayMacro   key.Binding
+	Rest        key.Binding
+	Backspace   key.Binding
+
+	// Columns and measures
+	InsertColumn     key.Binding
+	DeleteColumn     key.Binding
+	ShiftScope       key.Binding
+	AddMeasure       key.Binding
+	InsertMeasure    key.Binding
+	AppendMeasure    key.Binding
+	DeleteMeasure    key.Binding
+	DuplicateMeasure key.Binding
+	MoveMeasureLeft  key.Binding
+	MoveMeasureRight key.Binding
+	Analysis         key.Binding
+
+	// Select mode
+	SemitoneUp   key.Binding
+	SemitoneDown key.Binding
+	OctaveUp     key.Binding
+	OctaveDown   key.Binding
+	StringBelow  key.Binding
+	StringAbove  key.Binding
+}
+
+// NewBinding makes a binding whose help shows its keys, so a binding
+// changed by the config file still reads right in the help
+func NewBinding(desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(FormatKeys(keys), desc))
+}
+
+// Rebind replaces the keys of a binding, keeping its description. Keys
+// are named as bubbletea does, with "space" also taken for " ".
+func Rebind(b *key.Binding, keys []string) {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k
+		if k == "space" {
+			names[i] = " "
+		}
+	}
+	*b = NewBinding(b.Help().Desc, names...)
+}
+
+// keyLabels spells out keys whose names are unclear on screen
+var keyLabels = map[string]string{
+	" ": "space", "up": "↑", "down": "↓", "left": "←", "right": "→",
+}
+
+// FormatKeys joins key names for the help, e.g. "←/h"
+func FormatKeys(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		labels[i] = k
+		if label, ok := keyLabels[k]; ok {
+			labels[i] = label
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+func NewEditorKeyMap() EditorKeyMap {
+	return EditorKeyMap{
+		Left:         NewBinding("move left along the string", "h", "left"),
+		Right:        NewBinding("move right along the string", "l", "right"),
+		Up:           NewBinding("move to the higher string", "k", "up"),
+		Down:         NewBinding("move to the lower string", "j", "down"),
+		NextMeasure:  NewBinding("next measure", "w"),
+		PrevMeasure:  NewBinding("previous measure", "b"),
+		MeasureStart: NewBinding("start of the measure", "g"),
+		MeasureEnd:   NewBinding("end of the measure", "$"),
+		StringStart:  NewBinding("start of the string", "home"),
+		StringEnd:    NewBinding("end of the string", "end"),
+		NextMatch:    NewBinding("next search match", "n"),
+		PrevMatch:    NewBinding("previous search match", "N"),
+
+		PageUp:      NewBinding("scroll a page up", "pgup"),
+		PageDown:    NewBinding("scroll a page down", "pgdown"),
+		NextSection: NewBinding("next section", "]"),
+		PrevSection: NewBinding("previous section", "["),
+		JumpOlder:   NewBinding("back through the jump list", "ctrl+o"),
+		JumpNewer:   NewBinding("forward through the jump list (Ctrl+I)", "tab"),
+		SetMark:     NewBinding("set mark a-z at the cursor (adding measures moved to M)", "m"),
+		GoToMark:    NewBinding("jump to mark a-z (twice: back)", "'"),
+
+		Insert:    NewBinding("insert mode", "i"),
+		Normal:    NewBinding("back to normal mode", "esc"),
+		Select:    NewBinding("select a block of notes", "v"),
+		ChordLane: NewBinding("type chord symbols", "C"),
+		LyricLane: NewBinding("type lyric syllables", "L"),
+		Form:      NewBinding("repeats, endings and jumps (then a key)", "R"),
+
+		DeleteFret:  NewBinding("clear the fret under the cursor", "x"),
+		Delete:      NewBinding("clear over a motion (doubled: string)", "d"),
+		Yank:        NewBinding("yank over a motion (doubled: string)", "y"),
+		Change:      NewBinding("clear over a motion and insert", "c"),
+		Paste:       NewBinding("paste after the cursor", "p"),
+		PasteBefore: NewBinding("paste at the cursor", "P"),
+		Repeat:      NewBinding("repeat the last change", "."),
+		Record:      NewBinding("record macro a-z (again to stop)", "q"),
+		PlayMacro:   NewBinding("play macro a-z (twice: the last)", "@"),
+		Rest:        NewBinding("type a rest", "-"),
+		Backspace:   NewBinding("delete back", "backspace", "ctrl+h"),
+
+		InsertColumn:     NewBinding("insert a column, shifting the rest right", "O", "insert"),
+		DeleteColumn:     NewBinding("delete the column, shifting the rest left", "X", "delete"),
+		ShiftScope:       NewBinding("shift to the bar line / whole tab", "|"),
+		AddMeasure:       NewBinding("add a measure at the end (was m; :measure remove takes one off)", "M"),
+		InsertMeasure:    NewBinding("insert a measure before the cursor", "I"),
+		AppendMeasure:    NewBinding("insert a measure after the cursor", "A"),
+		DeleteMeasure:    NewBinding("delete the cursor measure", "D"),
+		DuplicateMeasure: NewBinding("duplicate the cursor measure", "+"),
+		MoveMeasureLeft:  NewBinding("move the measure left", "<"),
+		MoveMeasureRight: NewBinding("move the measure right", ">"),
+		Analysis:         NewBinding("show recognized chords", "ctrl+a"),
+
+		SemitoneUp:   NewBinding("transpose a semitone up", "+", "="),
+		SemitoneDown: NewBinding("transpose a semitone down", "-", "_"),
+		OctaveUp:     NewBinding("transpose an octave up", "}"),
+		OctaveDown:   NewBinding("transpose an octave down", "{"),
+		StringBelow:  NewBinding("same pitch on the lower string", "J"),
+		StringAbove:  NewBinding("same pitch on the higher string", "K"),
+	}
+}
+
+// Bindings returns the bindings by the names used in the config file
+func (k *EditorKeyMap) Bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"left": &k.Left, "right": &k.Right, "up": &k.Up, "down": &k.Down,
+		"next_measure": &k.NextMeasure, "prev_measure": &k.PrevMeasure,
+		"measure_start": &k.MeasureStart, "measure_end": &k.MeasureEnd,
+		"string_start": &k.StringStart, "string_end": &k.StringEnd,
+		"next_match": &k.NextMatch, "prev_match": &k.PrevMatch,
+		"page_up": &k.PageUp, "page_down": &k.PageDown,
+		"next_section": &k.NextSection, "prev_section": &k.PrevSection,
+		"jump_older": &k.JumpOlder, "jump_newer": &k.JumpNewer,
+		"set_mark": &k.SetMark, "go_to_mark": &k.GoToMark,
+		"insert": &k.Insert, "normal": &k.Normal, "select": &k.Select,
+		"chord_lane": &k.ChordLane, "lyric_lane": &k.LyricLane, "form": &k.Form,
+		"delete_fret": &k.DeleteFret, "delete": &k.Delete, "yank": &k.Yank, "change": &k.Change,
+		"paste": &k.Paste, "paste_before": &k.PasteBefore, "repeat": &k.Repeat,
+		"record": &k.Record, "play_macro": &k.PlayMacro, "rest": &k.Rest, "backspace": &k.Backspace,
+		"insert_column": &k.InsertColumn, "delete_column": &k.DeleteColumn, "shift_scope": &k.ShiftScope,
+		"add_measure": &k.AddMeasure, "insert_measure": &k.InsertMeasure, "append_measure": &k.AppendMeasure,
+		"delete_measure": &k.DeleteMeasure, "duplicate_measure": &k.DuplicateMeasure,
+		"move_measure_left": &k.MoveMeasureLeft, "move_measure_right": &k.MoveMeasureRight,
+		"analysis":    &k.Analysis,
+		"semitone_up": &k.SemitoneUp, "semitone_down": &k.SemitoneDown,
+		"octave_up": &k.OctaveUp, "octave_down": &k.OctaveDown,
+		"string_below": &k.StringBelow, "string_above": &k.StringAbove,
+	}
+}
+
+// KeyGroup is a titled list of bindings on the help screen
+type KeyGroup struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// note is a help line for keys that are not a binding, such as digits
+func note(keys, desc string) key.Binding {
+	return key.NewBinding(key.WithHelp(keys, desc))
+}
+
+// Groups returns the bindings for the help screen, by mode
+func (k EditorKeyMap) Groups() []KeyGroup {
+	return []KeyGroup{
+		{"Editor - Movement", []key.Binding{
+			k.Left, k.Right, k.Up, k.Down, k.NextMeasure, k.PrevMeasure, k.MeasureStart, k.MeasureEnd,
+			k.StringStart, k.StringEnd, k.PageUp, k.PageDown, k.NextSection, k.PrevSection,
+			k.NextMatch, k.PrevMatch, k.JumpOlder, k.JumpNewer, k.SetMark, k.GoToMark,
+			note("3l, 2w", "a count repeats a motion or command"),
+		}},
+		{"Editor - Normal", []key.Binding{
+			k.Insert, k.Select, k.ChordLane, k.LyricLane, k.Form,
+			k.DeleteFret, k.Delete, k.Yank, k.Change, k.Paste, k.PasteBefore, k.Repeat, k.Record, k.PlayMacro,
+			k.InsertColumn, k.DeleteColumn, k.ShiftScope, k.AddMeasure, k.InsertMeasure, k.AppendMeasure,
+			k.DeleteMeasure, k.DuplicateMeasure, k.MoveMeasureLeft, k.MoveMeasureRight, k.Analysis,
+		}},
+		{"Editor - Insert", []key.Binding{
+			note("0-9", "type a fret (two digits up to 24 make one fret)"),
+			k.Rest, k.Backspace, k.Normal,
+		}},
+		{"Editor - Select", []key.Binding{
+			note("movement", "extend the selection"),
+			k.SemitoneUp, k.SemitoneDown, k.OctaveUp, k.OctaveDown, k.StringBelow, k.StringAbove,
+			note(FormatKeys(append(k.Yank.Keys(), k.Delete.Keys()...)), "yank / clear the selection"),
+			k.Normal,
+		}},
+		{"Editor - Chord/Lyric", []key.Binding{
+			note("text", "type a chord symbol or syllable"),
+			note("space", "commit and jump to the next note"),
+			note("enter", "commit (empty removes)"),
+			note("←/→", "move along the lane"),
+			k.Normal,
+		}},
+	}
+}
+
+// motion reports whether the key is a motion an operator can act over
+func (k EditorKeyMap) motion(msg tea.KeyMsg) bool {
+	return key.Matches(msg, k.Left, k.Right, k.Up, k.Down, k.NextMeasure, k.PrevMeasure,
+		k.MeasureStart, k.MeasureEnd, k.StringStart, k.StringEnd, k.NextMatch, k.PrevMatch)
+}
+
+// movesOnly reports whether the key only moves the cursor
+func (k EditorKeyMap) movesOnly(msg tea.KeyMsg) bool {
+	return k.motion(msg) || key.Matches(msg, k.NextSection, k.PrevSection)
+}
+
+// changes reports whether the key changes the tab and is repeated by .
+func (k EditorKeyMap) changes(msg tea.KeyMsg) bool {
+	return key.Matches(msg, k.AddMeasure, k.InsertMeasure, k.AppendMeasure, k.DeleteMeasure,
+		k.DuplicateMeasure, k.MoveMeasureLeft, k.MoveMeasureRight, k.InsertColumn, k.DeleteColumn)
+}
+
+// repeats reports whether a count runs the key that many times
+func (k EditorKeyMap) repeats(msg tea.KeyMsg) bool {
+	return k.motion(msg) && !key.Matches(msg, k.MeasureStart, k.MeasureEnd, k.StringStart, k.StringEnd) ||
+		key.Matches(msg, k.NextSection, k.PrevSection, k.JumpOlder, k.JumpNewer) || k.changes(msg)
+}
+
+// keyNames maps key names back to the keys, for replaying commands
+var keyNames = func() map[string]tea.KeyType {
+	names := make(map[string]tea.KeyType)
+	for t := tea.KeyType(-128); t <= 127; t++ {
+		if t == tea.KeyRunes {
+			continue
+		}
+		if name := (tea.Key{Type: t}).String(); name != "" {
+			if _, seen := names[name]; !seen {
+				names[name] = t
+			}
+		}
+	}
+	return names
+}()
+
+// keyMsg builds the message of a key from its name, for replaying commands
+func keyMsg(name string) tea.KeyMsg {
+	alt := false
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		alt, name = true, rest
+	}
+	if t, ok := keyNames[name]; ok {
+		return tea.KeyMsg{Type: t, Alt: alt}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name), Alt: alt}
+}
+
+// firstKey returns the message of the first key of a binding
+func firstKey(b key.Binding) tea.KeyMsg {
+	if keys := b.Keys(); len(keys) > 0 {
+		return keyMsg(keys[0])
+	}
+	return tea.KeyMsg{}
+}
@@ -0,0 +1,115 @@
+// internal/ui/components/macros.go
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// maxMacroDepth limits macros playing macros, so a macro that plays
+// itself comes to an end
+const maxMacroDepth = 20
+
+// maxMacroKeys limits the keys a macro plays in all, macros it plays
+// included, so counted macros playing counted macros end in good time
+const maxMacroKeys = 10000
+
+// Macros returns the recorded macros by register
+func (m TabEditorModel) Macros() map[rune][]tea.KeyMsg {
+	return m.macros
+}
+
+// SetMacros replaces the recorded macros, e.g. to keep them when another
+// tab is opened
+func (m *TabEditorModel) SetMacros(macros map[rune][]tea.KeyMsg) {
+	m.macros = macros
+}
+
+// Recording returns the register a macro is being recorded into
+func (m TabEditorModel) Recording() (rune, bool) {
+	return m.recording, m.recording != 0
+}
+
+// recordKey adds a key to the macro being recorded. It returns true for
+// the q that ends the recording.
+func (m *TabEditorModel) recordKey(msg tea.KeyMsg) bool {
+	if m.recording == 0 || m.macroDepth > 0 {
+		return false
+	}
+	if key.Matches(msg, m.keys.Record) && m.editMode == models.EditNormal && !m.PendingKey() {
+		if m.macros == nil {
+			m.macros = make(map[rune][]tea.KeyMsg)
+		}
+		m.macros[m.recording] = m.macroKeys
+		m.status = fmt.Sprintf("Recorded %d key(s) into @%c", len(m.macroKeys), m.recording)
+		m.recording = 0
+		m.macroKeys = nil
+		return true
+	}
+	m.macroKeys = append(m.macroKeys, msg)
+	return false
+}
+
+// macroRegister takes the register named after q (start recording) or @
+// (play the macro count times; @@ plays the last one again)
+func (m *TabEditorModel) macroRegister(msg tea.KeyMsg) {
+	kind := m.pendingReg
+	count := max(m.count, 1)
+	m.pendingReg = 0
+	m.endCommand(false)
+
+	var reg rune
+	switch typed := msg.String(); {
+	case kind == '@' && key.Matches(msg, m.keys.PlayMacro):
+		reg = m.lastMacro
+	case len(typed) == 1 && typed[0] >= 'a' && typed[0] <= 'z':
+		reg = rune(typed[0])
+	}
+	if reg == 0 {
+		return
+	}
+
+	switch kind {
+	case 'q':
+		m.recording = reg
+		m.macroKeys = nil
+		m.status = fmt.Sprintf("Recording @%c (q to stop)", reg)
+	case '@':
+		m.playMacro(reg, count)
+	}
+}
+
+// playMacro feeds the keys of a macro through Update count times
+func (m *TabEditorModel) playMacro(reg rune, count int) {
+	keys := m.macros[reg]
+	if len(keys) == 0 {
+		m.status = fmt.Sprintf("Nothing recorded in @%c", reg)
+		return
+	}
+	if m.macroDepth >= maxMacroDepth {
+		m.status = "Macros nested too deeply"
+		return
+	}
+
+	m.lastMacro = reg
+	if m.macroDepth == 0 {
+		m.macroPlayed = 0
+	}
+	m.macroDepth++
+play:
+	for i := 0; i < count; i++ {
+		for _, key := range keys {
+			if m.macroPlayed >= maxMacroKeys {
+				m.status = fmt.Sprintf("Macro stopped after %d keys", maxMacroKeys)
+				break play
+			}
+			m.macroPlayed++
+			*m, _ = m.Update(key)
+		}
+	}
+	m.macroDepth--
+}
@@ -0,0 +1,103 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keysOf returns the messages of the keys of typed
+func keysOf(typed string) []tea.KeyMsg {
+	var keys []tea.KeyMsg
+	for _, r := range typed {
+		keys = append(keys, keyMsg(string(r)))
+	}
+	return keys
+}
+
+func TestRecordAndPlayMacro(t *testing.T) {
+	m := typeKeys(newTestEditor(), "qaxllq")
+	if _, ok := m.Recording(); ok {
+		t.Fatal("Expected the recording to end with q")
+	}
+	if got := len(m.Macros()['a']); got != 3 {
+		t.Fatalf("Expected 3 keys recorded, got %d", got)
+	}
+	if expected := cleared([2]int{0, 1}); m.GetTab().Content[0] != expected {
+		t.Errorf("Expected the keys to run while recording:\nexpected %q\ngot      %q", expected, m.GetTab().Content[0])
+	}
+
+	steps := []struct {
+		keys     string
+		expected string
+		position int
+	}{
+		{"@a", cleared([2]int{0, 3}), 4},
+		{"2@a", cleared([2]int{0, 7}), 8},
+		{"@@", cleared([2]int{0, 9}), 10},
+	}
+	for _, step := range steps {
+		m = typeKeys(m, step.keys)
+		if got := m.GetTab().Content[0]; got != step.expected {
+			t.Errorf("%q:\nexpected %q\ngot      %q", step.keys, step.expected, got)
+		}
+		if got := m.GetCursor().Position; got != step.position {
+			t.Errorf("%q: expected the cursor at %d, got %d", step.keys, step.position, got)
+		}
+	}
+
+	m = typeKeys(m, "@z")
+	if m.status != "Nothing recorded in @z" {
+		t.Errorf("Expected an empty register reported, got %q", m.status)
+	}
+}
+
+func TestRecordMacroPlayingMacro(t *testing.T) {
+	// Playing @b while recording @a records the keys that play it, not the
+	// keys @b holds
+	m := typeKeys(newTestEditor(), "qbxllqqa2@bq")
+	if got := len(m.Macros()['a']); got != 3 {
+		t.Fatalf("Expected @a to hold 2@b, got %d key(s)", got)
+	}
+	m = typeKeys(m, "@a")
+	if expected := cleared([2]int{0, 9}); m.GetTab().Content[0] != expected {
+		t.Errorf("Expected @a to play @b twice:\nexpected %q\ngot      %q", expected, m.GetTab().Content[0])
+	}
+}
+
+func TestMacroLimits(t *testing.T) {
+	// A macro playing itself stops at the depth limit
+	m := newTestEditor()
+	m.SetMacros(map[rune][]tea.KeyMsg{'a': keysOf("l@a")})
+	m = typeKeys(m, "@a")
+	if got := m.GetCursor().Position; got != maxMacroDepth {
+		t.Errorf("Expected the cursor moved once per level, to %d, got %d", maxMacroDepth, got)
+	}
+
+	// Counted macros playing counted macros would play 9^20 keys
+	macros := make(map[rune][]tea.KeyMsg)
+	for reg := 'a'; reg < 'a'+maxMacroDepth-1; reg++ {
+		macros[reg] = keysOf("9@" + string(reg+1))
+	}
+	macros['a'+maxMacroDepth-1] = keysOf("lh")
+	m = newTestEditor()
+	m.SetMacros(macros)
+
+	start := time.Now()
+	m = typeKeys(m, "9@a")
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the key limit to end the macro, took %v", elapsed)
+	}
+	if !strings.HasPrefix(m.status, "Macro stopped after") {
+		t.Errorf("Expected the macro reported stopped, got %q", m.status)
+	}
+
+	// The limit is per macro typed, not for the session
+	before := m.GetCursor().Position
+	m = typeKeys(m, "@"+string('a'+maxMacroDepth-1)+"l")
+	if got := m.GetCursor().Position; got != before+1 {
+		t.Errorf("Expected the next macro to play, cursor at %d rather than %d", got, before+1)
+	}
+}
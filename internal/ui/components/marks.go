@@ -0,0 +1,111 @@
+// internal/ui/components/marks.go
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// maxJumps is the number of positions kept in the jump list
+const maxJumps = 100
+
+// markKey takes the letter named after m (set a mark) or ' (jump to it).
+// A second ' jumps back to where the last jump started.
+func (m *TabEditorModel) markKey(msg tea.KeyMsg) {
+	kind := m.pendingReg
+	name := msg.String()
+	m.pendingReg = 0
+	m.endCommand(false)
+
+	if kind == '\'' && key.Matches(msg, m.keys.GoToMark) {
+		if len(m.jumps) == 0 {
+			m.status = "No previous jump"
+			return
+		}
+		target := m.jumps[len(m.jumps)-1]
+		m.pushJump()
+		m.moveTo(target)
+		return
+	}
+	if len(name) != 1 || name[0] < 'a' || name[0] > 'z' {
+		return
+	}
+
+	switch kind {
+	case 'm':
+		m.tab.SetMark(name, m.cursor.String, m.cursor.Position)
+		m.status = fmt.Sprintf("Mark %s set", name)
+		m.changed = true
+	case '\'':
+		mark, ok := m.tab.MarkAt(name)
+		if !ok {
+			m.status = fmt.Sprintf("Mark %s not set", name)
+			return
+		}
+		m.pushJump()
+		m.moveTo(models.Position{String: mark.String, Position: mark.Position})
+	}
+}
+
+// pushJump remembers the cursor before a jump, dropping an older entry
+// for the same cell. Jumping after going back with Ctrl+O forgets the
+// newer positions, as browser history does.
+func (m *TabEditorModel) pushJump() {
+	// A new slice, so editors sharing the list (such as the copy moved to
+	// find a motion) never write over each other
+	jumps := make([]models.Position, 0, m.jumpIdx+1)
+	for _, pos := range m.jumps[:m.jumpIdx] {
+		if pos != m.cursor {
+			jumps = append(jumps, pos)
+		}
+	}
+	jumps = append(jumps, m.cursor)
+	if len(jumps) > maxJumps {
+		jumps = jumps[len(jumps)-maxJumps:]
+	}
+	m.jumps = jumps
+	m.jumpIdx = len(jumps)
+}
+
+// jumpOlder goes back to the position before the last jump (Ctrl+O)
+func (m *TabEditorModel) jumpOlder() {
+	if m.jumpIdx == 0 {
+		m.status = "At the oldest jump"
+		return
+	}
+	if m.jumpIdx == len(m.jumps) {
+		// Keep the current position to come back to with Ctrl+I
+		m.jumps = append(m.jumps[:len(m.jumps):len(m.jumps)], m.cursor)
+	}
+	m.jumpIdx--
+	m.moveTo(m.jumps[m.jumpIdx])
+}
+
+// jumpNewer undoes a jumpOlder (Ctrl+I)
+func (m *TabEditorModel) jumpNewer() {
+	if !m.HasNewerJump() {
+		m.status = "At the newest jump"
+		return
+	}
+	m.jumpIdx++
+	m.moveTo(m.jumps[m.jumpIdx])
+}
+
+// HasNewerJump reports whether Ctrl+O went back from a position that
+// Ctrl+I can return to
+func (m TabEditorModel) HasNewerJump() bool {
+	return m.jumpIdx < len(m.jumps)-1
+}
+
+// moveTo puts the cursor on a cell, kept within a tab that may have
+// shrunk since the position was saved
+func (m *TabEditorModel) moveTo(pos models.Position) {
+	m.cursor.String = min(max(pos.String, 0), 5)
+	m.cursor.Position = min(max(pos.Position, 0), m.tab.GetTotalLength()-1)
+	m.updateViewportForCursor()
+	m.changed = true
+}
@@ -0,0 +1,115 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// pressKeys sends keys named as in the config file, such as "ctrl+o"
+func pressKeys(m TabEditorModel, names ...string) TabEditorModel {
+	for _, name := range names {
+		m, _ = m.Update(keyMsg(name))
+	}
+	return m
+}
+
+func TestMarks(t *testing.T) {
+	m := typeKeys(newTestEditor(), "2wjlma")
+	marked := models.Position{String: 1, Position: 33}
+	if mark, ok := m.GetTab().MarkAt("a"); !ok || mark.String != 1 || mark.Position != 33 {
+		t.Fatalf("Expected mark a on string 1 at column 33, got %+v", mark)
+	}
+
+	start := models.Position{String: 0, Position: 32}
+	m = typeKeys(m, "bk")
+	steps := []struct {
+		keys   string
+		cursor models.Position
+	}{
+		{"'a", marked},
+		{"''", start},  // Back to where the jump started
+		{"''", marked}, // And back again
+		{"'z", marked}, // Not set
+		{"'", marked},  // Waiting for the mark
+		{"M", marked},  // Not a mark, so nothing happens
+	}
+	for _, step := range steps {
+		m = typeKeys(m, step.keys)
+		if got := m.GetCursor(); got != step.cursor {
+			t.Errorf("%q: expected the cursor at %+v, got %+v", step.keys, step.cursor, got)
+		}
+	}
+	if m.GetTab().GetMeasureCount() != 4 {
+		t.Errorf("Expected a key after ' not to run, got %d measures", m.GetTab().GetMeasureCount())
+	}
+
+	// m takes a mark name; adding a measure is on M
+	m = typeKeys(newTestEditor(), "mM")
+	if m.GetTab().GetMeasureCount() != 4 || len(m.GetTab().Marks) != 0 {
+		t.Errorf("Expected mM to do nothing, got %d measures and marks %+v",
+			m.GetTab().GetMeasureCount(), m.GetTab().Marks)
+	}
+	m = typeKeys(m, "M")
+	if m.GetTab().GetMeasureCount() != 5 {
+		t.Errorf("Expected M to add a measure, got %d measures", m.GetTab().GetMeasureCount())
+	}
+}
+
+func TestJumpList(t *testing.T) {
+	at := func(pos int) models.Position {
+		return models.Position{String: 0, Position: pos}
+	}
+
+	// Marks at 0 and 16; plain motions are not jumps
+	m := typeKeys(newTestEditor(), "mawmb2w")
+	m = typeKeys(m, "'a")
+	m = typeKeys(m, "'b")
+
+	steps := []struct {
+		key    string
+		cursor models.Position
+		newer  bool
+	}{
+		{"ctrl+o", at(0), true},
+		{"ctrl+o", at(48), true},
+		{"ctrl+o", at(48), true}, // At the oldest jump
+		{"tab", at(0), true},
+		{"tab", at(16), false},
+		{"tab", at(16), false}, // At the newest jump
+		{"ctrl+o", at(0), true},
+	}
+	for i, step := range steps {
+		m = pressKeys(m, step.key)
+		if got := m.GetCursor(); got != step.cursor {
+			t.Errorf("Step %d (%s): expected the cursor at %+v, got %+v", i, step.key, step.cursor, got)
+		}
+		if m.HasNewerJump() != step.newer {
+			t.Errorf("Step %d (%s): expected HasNewerJump %v", i, step.key, step.newer)
+		}
+	}
+
+	// A jump after going back forgets the newer positions
+	m = typeKeys(m, "'b")
+	if m.HasNewerJump() {
+		t.Error("Expected a new jump to drop the positions after it")
+	}
+	m = pressKeys(m, "ctrl+o")
+	if got := m.GetCursor(); got != at(0) {
+		t.Errorf("Expected Ctrl+O to return to column 0, got %+v", got)
+	}
+	m = pressKeys(m, "ctrl+o")
+	if got := m.GetCursor(); got != at(48) {
+		t.Errorf("Expected the position before it kept, got %+v", got)
+	}
+}
+
+func TestJumpListLimit(t *testing.T) {
+	m := typeKeys(newTestEditor(), "ma")
+	for i := 0; i < maxJumps+10; i++ {
+		m = typeKeys(m, "l'a")
+	}
+	if len(m.jumps) > maxJumps {
+		t.Errorf("Expected at most %d jumps kept, got %d", maxJumps, len(m.jumps))
+	}
+}
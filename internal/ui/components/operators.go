@@ -0,0 +1,333 @@
+// internal/ui/components/operators.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// Vim-style normal mode: a count before a key repeats it, an operator
+// (d, y, c) acts on the cells a following motion moves over, and . repeats
+// the last change. The string under the cursor plays the part of a line.
+
+// updateNormal handles counts, operators, paste and dot-repeat in normal
+// mode. It returns false for keys that run once as usual.
+func (m *TabEditorModel) updateNormal(msg tea.KeyMsg) bool {
+	typed := msg.String()
+	keys := m.keys
+	m.command = append(m.command, msg)
+
+	switch {
+	case m.pendingReg == 'm' || m.pendingReg == '\'':
+		m.markKey(msg)
+		return true
+
+	case m.pendingReg != 0:
+		m.macroRegister(msg)
+		return true
+
+	case len(typed) == 1 && typed[0] >= '1' && typed[0] <= '9', typed == "0" && m.count > 0:
+		m.count = m.count*10 + int(typed[0]-'0')
+		return true
+
+	case m.operator != "":
+		m.applyOperator(msg)
+		return true
+
+	case key.Matches(msg, keys.Repeat):
+		m.repeatChange()
+		return true
+
+	case key.Matches(msg, keys.Record):
+		m.pendingReg = 'q'
+		return true
+	case key.Matches(msg, keys.PlayMacro):
+		m.pendingReg = '@'
+		return true
+	case key.Matches(msg, keys.SetMark):
+		m.pendingReg = 'm'
+		return true
+	case key.Matches(msg, keys.GoToMark):
+		m.pendingReg = '\''
+		return true
+
+	case key.Matches(msg, keys.Delete, keys.Yank, keys.Change):
+		m.operator = operatorOf(keys, msg)
+		m.opCount = max(m.count, 1)
+		m.count = 0
+		return true
+
+	case key.Matches(msg, keys.DeleteFret):
+		sel := models.Selection{
+			FirstString: m.cursor.String,
+			LastString:  m.cursor.String,
+			Start:       m.cursor.Position,
+			End:         m.cursor.Position + max(m.count, 1) - 1,
+		}
+		m.register = m.tab.Yank(sel)
+		m.tab.ClearBlock(sel)
+		m.changed = true
+		m.endCommand(true)
+		return true
+
+	case key.Matches(msg, keys.Paste, keys.PasteBefore):
+		m.paste(key.Matches(msg, keys.Paste), max(m.count, 1))
+		m.endCommand(true)
+		return true
+
+	case key.Matches(msg, keys.Insert):
+		m.endCommand(false)
+		m.SetEditMode(models.EditInsert)
+		return true
+
+	case key.Matches(msg, keys.Form):
+		m.pendingForm = true
+		m.count = 0
+		return true
+
+	case keys.repeats(msg):
+		for i := 0; i < max(m.count, 1); i++ {
+			m.handleKey(msg)
+		}
+		m.endCommand(keys.changes(msg))
+		return true
+	}
+
+	m.command = nil
+	m.count = 0
+	return false
+}
+
+// operatorOf names the operator bound to the key: d, y or c
+func operatorOf(keys EditorKeyMap, msg tea.KeyMsg) string {
+	switch {
+	case key.Matches(msg, keys.Delete):
+		return "d"
+	case key.Matches(msg, keys.Yank):
+		return "y"
+	}
+	return "c"
+}
+
+// operatorBinding returns the binding of an operator named by operatorOf
+func operatorBinding(keys EditorKeyMap, op string) key.Binding {
+	switch op {
+	case "d":
+		return keys.Delete
+	case "y":
+		return keys.Yank
+	}
+	return keys.Change
+}
+
+// endCommand finishes the command being typed, remembering it for . when
+// it changed the tab
+func (m *TabEditorModel) endCommand(change bool) {
+	if change && !m.replaying {
+		m.lastChange = m.command
+	}
+	m.command = nil
+	m.count = 0
+	m.operator = ""
+}
+
+// applyOperator runs the pending operator over the cells the motion key
+// moves across. Doubling the operator (dd, yy) takes whole strings.
+func (m *TabEditorModel) applyOperator(msg tea.KeyMsg) {
+	op := m.operator
+	count := m.opCount * max(m.count, 1)
+
+	sel, linewise, ok := m.motionSelection(msg, op, count)
+	if !ok {
+		m.endCommand(false)
+		return
+	}
+
+	m.register = m.tab.Yank(sel)
+	m.register.Linewise = linewise
+
+	switch op {
+	case "y":
+		m.status = fmt.Sprintf("Yanked %d string(s) × %d column(s)", len(m.register.Lines), m.register.Width())
+		m.endCommand(false)
+	case "d", "c":
+		m.tab.ClearBlock(sel)
+		m.changed = true
+		if op == "d" {
+			m.endCommand(true)
+		}
+	}
+
+	m.cursor.String = sel.FirstString
+	if !linewise {
+		m.cursor.Position = sel.Start
+	}
+
+	if op == "c" {
+		// The change is remembered with the text typed, when insert mode ends
+		keys := m.command
+		m.endCommand(false)
+		m.SetEditMode(models.EditInsert)
+		if !m.replaying {
+			m.insertKeys = keys
+		}
+	}
+}
+
+// motionSelection returns the cells between the cursor and where the
+// motion would take it, repeated count times. Motions across strings and
+// the doubled operator select whole strings.
+func (m *TabEditorModel) motionSelection(msg tea.KeyMsg, op string, count int) (models.Selection, bool, bool) {
+	keys := m.keys
+	sel := models.Selection{FirstString: m.cursor.String, LastString: m.cursor.String}
+	whole := func() {
+		sel.Start = 0
+		sel.End = m.tab.GetTotalLength() - 1
+	}
+
+	switch {
+	case key.Matches(msg, operatorBinding(keys, op)):
+		sel.LastString = min(m.cursor.String+count-1, 5)
+		whole()
+		return sel, true, true
+	case key.Matches(msg, keys.Down):
+		sel.LastString = min(m.cursor.String+count, 5)
+		whole()
+		return sel, true, true
+	case key.Matches(msg, keys.Up):
+		sel.FirstString = max(m.cursor.String-count, 0)
+		whole()
+		return sel, true, true
+	case !keys.motion(msg):
+		return sel, false, false
+	}
+
+	// Move a copy of the editor to find where the motion ends
+	moved := *m
+	moves := count
+	if key.Matches(msg, keys.MeasureEnd) {
+		// 2$ reaches the end of the next measure
+		for i := 0; i < count-1; i++ {
+			moved.handleKey(firstKey(keys.NextMeasure))
+		}
+		moves = 1
+	}
+	for i := 0; i < moves; i++ {
+		moved.handleKey(msg)
+	}
+	target := moved.cursor.Position
+	from := m.cursor.Position
+
+	// $ and end take the cell they land on, as does w stopping at the end
+	// of the tab rather than at the next measure
+	inclusive := key.Matches(msg, keys.MeasureEnd, keys.StringEnd) ||
+		(key.Matches(msg, keys.NextMeasure) && target%models.MeasureLength != 0)
+
+	if target >= from {
+		sel.Start, sel.End = from, target-1
+		if inclusive {
+			sel.End = target
+		}
+	} else {
+		sel.Start, sel.End = target, from-1
+	}
+	return sel, false, sel.End >= sel.Start
+}
+
+// paste writes the register after the cursor column (or on it, with P)
+// count times side by side. Whole strings are pasted over the strings
+// below the cursor (or from it, with P).
+func (m *TabEditorModel) paste(after bool, count int) {
+	if m.register.Empty() {
+		m.status = "Nothing to paste"
+		return
+	}
+	if m.register.Linewise {
+		str := m.cursor.String
+		if after {
+			str++
+		}
+		if str > 5 {
+			m.status = "No string below to paste on"
+			return
+		}
+		m.tab.PasteClip(m.register, str, 0)
+		m.cursor.String = str
+		m.changed = true
+		return
+	}
+
+	pos := m.cursor.Position
+	if after {
+		pos++
+	}
+	if pos >= m.tab.GetTotalLength() {
+		m.status = "No column after the cursor to paste on"
+		return
+	}
+	for i := 0; i < count; i++ {
+		m.tab.PasteClip(m.register, m.cursor.String, pos+i*m.register.Width())
+	}
+	m.cursor.Position = pos
+	m.changed = true
+}
+
+// repeatChange replays the last change. A count replaces the count the
+// change was made with.
+func (m *TabEditorModel) repeatChange() {
+	count := m.count
+	m.command = nil
+	m.count = 0
+	if len(m.lastChange) == 0 {
+		return
+	}
+
+	keys := m.lastChange
+	if count > 0 {
+		i := 0
+		for i < len(keys) && strings.ContainsAny(keys[i].String(), "0123456789") && len(keys[i].String()) == 1 {
+			i++
+		}
+		keys = append(digitsToKeys(fmt.Sprint(count)), keys[i:]...)
+	}
+
+	m.replaying = true
+	for _, key := range keys {
+		*m, _ = m.Update(key)
+	}
+	m.replaying = false
+}
+
+// digitsToKeys turns a count into the keys that type it
+func digitsToKeys(digits string) []tea.KeyMsg {
+	var keys []tea.KeyMsg
+	for _, digit := range digits {
+		keys = append(keys, keyMsg(string(digit)))
+	}
+	return keys
+}
+
+// PendingCommand returns the keys typed so far of an unfinished command,
+// such as "3d" or "R"
+func (m TabEditorModel) PendingCommand() string {
+	var typed strings.Builder
+	for _, key := range m.command {
+		typed.WriteString(key.String())
+	}
+	return typed.String()
+}
+
+// Register returns the contents of the unnamed register
+func (m TabEditorModel) Register() models.Clip {
+	return m.register
+}
+
+// SetRegister replaces the contents of the unnamed register
+func (m *TabEditorModel) SetRegister(clip models.Clip) {
+	m.register = clip
+}
@@ -0,0 +1,126 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// newTestEditor returns an editor on a 4-measure tab with a note on every
+// other column of the top string
+func newTestEditor() TabEditorModel {
+	tab := models.NewEmptyTab("Test")
+	tab.Content[0] = strings.Repeat("1-2-3-4-5-6-7-8-", 4)
+	m := NewTabEditor(tab)
+	m.SetSize(80, 30)
+	return m
+}
+
+// typeKeys sends each key of typed to the editor as if typed one by one
+func typeKeys(m TabEditorModel, typed string) TabEditorModel {
+	for _, r := range typed {
+		m, _ = m.Update(keyMsg(string(r)))
+	}
+	return m
+}
+
+// cleared returns the top string of the test tab with each span of cells
+// blanked
+func cleared(spans ...[2]int) string {
+	line := []byte(strings.Repeat("1-2-3-4-5-6-7-8-", 4))
+	for _, span := range spans {
+		for i := span[0]; i < span[1]; i++ {
+			line[i] = '-'
+		}
+	}
+	return string(line)
+}
+
+func TestCountsAndOperators(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     string
+		expected string
+		cursor   models.Position
+	}{
+		{"count on a motion", "3l", cleared(), models.Position{String: 0, Position: 3}},
+		{"count on a measure motion", "2w", cleared(), models.Position{String: 0, Position: 32}},
+		{"operator and motion", "dw", cleared([2]int{0, 16}), models.Position{String: 0, Position: 0}},
+		{"count on the motion", "d2w", cleared([2]int{0, 32}), models.Position{String: 0, Position: 0}},
+		{"count on the operator", "2dw", cleared([2]int{0, 32}), models.Position{String: 0, Position: 0}},
+		{"counts multiply", "2d3l", cleared([2]int{0, 6}), models.Position{String: 0, Position: 0}},
+		{"backward motion", "5ld2h", cleared([2]int{3, 5}), models.Position{String: 0, Position: 3}},
+		{"to the measure end", "4ld$", cleared([2]int{4, 16}), models.Position{String: 0, Position: 4}},
+		{"count on x", "3x", cleared([2]int{0, 3}), models.Position{String: 0, Position: 0}},
+		{"doubled operator", "dd", cleared([2]int{0, 64}), models.Position{String: 0, Position: 0}},
+		{"yank leaves the tab", "yw", cleared(), models.Position{String: 0, Position: 0}},
+		{"unknown motion cancels", "dzl", cleared(), models.Position{String: 0, Position: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := typeKeys(newTestEditor(), tt.keys)
+			if got := m.GetTab().Content[0]; got != tt.expected {
+				t.Errorf("%q:\nexpected %q\ngot      %q", tt.keys, tt.expected, got)
+			}
+			if got := m.GetCursor(); got != tt.cursor {
+				t.Errorf("%q: expected the cursor at %+v, got %+v", tt.keys, tt.cursor, got)
+			}
+			if m.PendingCommand() != "" {
+				t.Errorf("%q: expected no pending command, got %q", tt.keys, m.PendingCommand())
+			}
+		})
+	}
+}
+
+func TestOperatorRegister(t *testing.T) {
+	m := typeKeys(newTestEditor(), "2y4l")
+	reg := m.Register()
+	if reg.Linewise || len(reg.Lines) != 1 || reg.Lines[0] != "1-2-3-4-" {
+		t.Errorf("Expected 8 columns yanked, got %+v", reg)
+	}
+
+	m = typeKeys(newTestEditor(), "2dd")
+	reg = m.Register()
+	if !reg.Linewise || len(reg.Lines) != 2 {
+		t.Errorf("Expected 2 whole strings deleted into the register, got %+v", reg)
+	}
+	if m.GetTab().Content[0] != cleared([2]int{0, 64}) {
+		t.Errorf("Expected the top string cleared, got %q", m.GetTab().Content[0])
+	}
+
+	// Whole strings paste over the string below the cursor
+	m = typeKeys(newTestEditor(), "yyp")
+	if m.GetTab().Content[1] != m.GetTab().Content[0] {
+		t.Errorf("Expected the string pasted below, got %q", m.GetTab().Content[1])
+	}
+	if m.GetCursor().String != 1 {
+		t.Errorf("Expected the cursor on the pasted string, got %+v", m.GetCursor())
+	}
+}
+
+func TestDotRepeat(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     string
+		expected string
+	}{
+		{"repeats an operator", "dww.", cleared([2]int{0, 32})},
+		{"keeps the count", "3xw.", cleared([2]int{0, 3}, [2]int{16, 19})},
+		{"a new count replaces it", "3xw2.", cleared([2]int{0, 3}, [2]int{16, 18})},
+		{"repeats the counted operator", "d2ww.", cleared([2]int{0, 48})},
+		{"ignores motions", "xl.", cleared([2]int{0, 2})},
+		{"ignores yanks", "xlyw.", cleared([2]int{0, 2})},
+		{"nothing to repeat", ".", cleared()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := typeKeys(newTestEditor(), tt.keys)
+			if got := m.GetTab().Content[0]; got != tt.expected {
+				t.Errorf("%q:\nexpected %q\ngot      %q", tt.keys, tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// internal/ui/components/search.go
+package components
+
+import (
+	"fmt"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// Search looks for the notes of a query, as read by models.ParseSearch,
+// and moves to the next match after the cursor, or before it when
+// backward. Every match is highlighted until ClearSearch.
+func (m *TabEditorModel) Search(query string, backward bool) {
+	m.status = ""
+	terms, ok := models.ParseSearch(query)
+	if !ok {
+		m.status = fmt.Sprintf("Not a fret, note or lick: %s", query)
+		return
+	}
+	m.searchQuery = query
+	m.searchTerms = terms
+	m.searchBackward = backward
+	m.showMatches = true
+	m.nextMatch(false)
+}
+
+// ClearSearch stops highlighting the matches of the last search; n and N
+// still find them
+func (m *TabEditorModel) ClearSearch() {
+	m.showMatches = false
+	m.changed = true
+}
+
+// searchMatches returns the matches of the last search in the tab as it
+// is now
+func (m TabEditorModel) searchMatches() []models.SearchMatch {
+	if !m.showMatches {
+		return nil
+	}
+	return m.tab.Search(m.searchTerms)
+}
+
+// nextMatch moves to the next match of the last search in its direction,
+// or the other way for N, wrapping around the ends of the tab
+func (m *TabEditorModel) nextMatch(reverse bool) {
+	if m.searchTerms == nil {
+		m.status = "No previous search"
+		return
+	}
+	prompt := "/"
+	backward := m.searchBackward != reverse
+	if m.searchBackward {
+		prompt = "?"
+	}
+
+	matches := m.tab.Search(m.searchTerms)
+	m.showMatches = true
+	m.changed = true
+	if len(matches) == 0 {
+		m.status = "Pattern not found: " + m.searchQuery
+		return
+	}
+
+	idx, wrapped := -1, false
+	if backward {
+		for i := len(matches) - 1; i >= 0 && idx == -1; i-- {
+			if matches[i].Start < m.cursor.Position {
+				idx = i
+			}
+		}
+		if idx == -1 {
+			idx, wrapped = len(matches)-1, true
+		}
+	} else {
+		for i := 0; i < len(matches) && idx == -1; i++ {
+			if matches[i].Start > m.cursor.Position {
+				idx = i
+			}
+		}
+		if idx == -1 {
+			idx, wrapped = 0, true
+		}
+	}
+
+	match := matches[idx]
+	m.pushJump()
+	m.moveTo(models.Position{String: match.Notes[0].String, Position: match.Start})
+	m.status = fmt.Sprintf("%s%s [%d/%d]", prompt, m.searchQuery, idx+1, len(matches))
+	if wrapped {
+		m.status += " (wrapped)"
+	}
+}
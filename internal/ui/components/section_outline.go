@@ -0,0 +1,137 @@
+// internal/ui/components/section_outline.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// OutlineWidth is the width of the section outline panel, including its border
+const OutlineWidth = 30
+
+type SectionOutlineModel struct {
+	sections []models.Section
+	cursor   int
+	current  int // Section containing the editor cursor, -1 if none
+	focused  bool
+	theme    Theme
+}
+
+func NewSectionOutline() SectionOutlineModel {
+	return SectionOutlineModel{current: -1, theme: DarkTheme()}
+}
+
+func (m *SectionOutlineModel) SetTheme(theme Theme) {
+	m.theme = theme
+}
+
+// SetSections refreshes the list and marks the section under the editor cursor
+func (m *SectionOutlineModel) SetSections(sections []models.Section, current int) {
+	m.sections = sections
+	m.current = current
+	if m.cursor >= len(sections) {
+		m.cursor = len(sections) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *SectionOutlineModel) Focus() {
+	m.focused = true
+	if m.current >= 0 {
+		m.cursor = m.current
+	}
+}
+
+func (m *SectionOutlineModel) Blur() {
+	m.focused = false
+}
+
+func (m SectionOutlineModel) Focused() bool {
+	return m.focused
+}
+
+func (m SectionOutlineModel) Update(msg tea.Msg) (SectionOutlineModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "j", "down":
+			if m.cursor < len(m.sections)-1 {
+				m.cursor++
+			}
+		case "home", "g":
+			m.cursor = 0
+		case "end", "G":
+			m.cursor = len(m.sections) - 1
+		}
+	}
+	return m, nil
+}
+
+// Selected returns the section under the outline cursor
+func (m SectionOutlineModel) Selected() (models.Section, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.sections) {
+		return models.Section{}, false
+	}
+	return m.sections[m.cursor], true
+}
+
+func (m SectionOutlineModel) View() string {
+	innerWidth := OutlineWidth - 4 // Border and padding
+
+	lines := []string{lipgloss.NewStyle().Bold(true).Render("Sections")}
+	if len(m.sections) == 0 {
+		lines = append(lines, m.theme.Faint.
+			Render("None yet. Press S in\nthe editor to start one."))
+	}
+
+	for i, section := range m.sections {
+		measures := fmt.Sprintf("%d-%d", section.Start+1, section.End+1)
+		if section.Start == section.End {
+			measures = fmt.Sprintf("%d", section.Start+1)
+		}
+
+		label := fmt.Sprintf("%-2s %s", models.RehearsalLetter(i), section.Name)
+		space := innerWidth - len([]rune(label)) - len(measures)
+		if space < 1 {
+			runes := []rune(label)
+			keep := len(runes) + space - 2
+			if keep < 0 {
+				keep = 0
+			}
+			label = string(runes[:keep]) + "…"
+			space = 1
+		}
+		item := label + strings.Repeat(" ", space) + measures
+
+		style := lipgloss.NewStyle()
+		switch {
+		case m.focused && i == m.cursor:
+			style = m.theme.Selected
+		case i == m.current:
+			style = m.theme.Section
+		}
+		lines = append(lines, style.Render(item))
+	}
+
+	if m.focused {
+		lines = append(lines, "", m.theme.Faint.
+			Render("Enter: Jump • Esc: Back\no: Close"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.border(m.focused)).
+		Padding(0, 1).
+		Width(OutlineWidth - 2).
+		Render(strings.Join(lines, "\n"))
+}
@@ -4,17 +4,20 @@ package components
 import (
 	"fmt"
 	"time"
-
-	"github.com/charmbracelet/lipgloss"
 )
 
 type StatusBarModel struct {
 	message   string
 	timestamp time.Time
+	theme     Theme
 }
 
 func NewStatusBar() StatusBarModel {
-	return StatusBarModel{}
+	return StatusBarModel{theme: DarkTheme()}
+}
+
+func (m *StatusBarModel) SetTheme(theme Theme) {
+	m.theme = theme
 }
 
 func (m *StatusBarModel) SetStatus(message string) {
@@ -24,25 +27,19 @@ func (m *StatusBarModel) SetStatus(message string) {
 
 func (m StatusBarModel) View() string {
 	if m.message == "" {
-		return lipgloss.NewStyle().
-			Background(lipgloss.Color("8")).
-			Foreground(lipgloss.Color("15")).
+		return m.theme.Status.
 			Width(80).
 			Render(" tuitar - Ready")
 	}
 
 	// Show message for 3 seconds, then clear
 	if time.Since(m.timestamp) > 3*time.Second {
-		return lipgloss.NewStyle().
-			Background(lipgloss.Color("8")).
-			Foreground(lipgloss.Color("15")).
+		return m.theme.Status.
 			Width(80).
 			Render(" Guitar Tab TUI - Ready")
 	}
 
-	return lipgloss.NewStyle().
-		Background(lipgloss.Color("11")).
-		Foreground(lipgloss.Color("0")).
+	return m.theme.Message.
 		Width(80).
 		Render(fmt.Sprintf(" %s", m.message))
 }
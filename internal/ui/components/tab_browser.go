@@ -18,6 +18,7 @@ type TabBrowserModel struct {
 	viewport viewport.Model
 	width    int
 	height   int
+	theme    Theme
 }
 
 func NewTabBrowser(tabs []models.Tab) TabBrowserModel {
@@ -26,9 +27,14 @@ func NewTabBrowser(tabs []models.Tab) TabBrowserModel {
 	return TabBrowserModel{
 		tabs:     tabs,
 		viewport: vp,
+		theme:    DarkTheme(),
 	}
 }
 
+func (m *TabBrowserModel) SetTheme(theme Theme) {
+	m.theme = theme
+}
+
 func (m *TabBrowserModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
@@ -37,6 +43,17 @@ func (m *TabBrowserModel) SetSize(width, height int) {
 }
 
 func (m TabBrowserModel) Update(msg tea.Msg) (TabBrowserModel, tea.Cmd) {
+	// The wheel moves the cursor rather than scrolling past it
+	if msg, ok := msg.(tea.MouseMsg); ok {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.SetCursor(m.cursor - 1)
+		case tea.MouseButtonWheelDown:
+			m.SetCursor(m.cursor + 1)
+		}
+		return m, nil
+	}
+
 	if msg, ok := msg.(tea.KeyMsg); ok {
 		switch msg.String() {
 		case "k", "up":
@@ -61,8 +78,7 @@ func (m TabBrowserModel) Update(msg tea.Msg) (TabBrowserModel, tea.Cmd) {
 
 func (m TabBrowserModel) View() string {
 	if len(m.tabs) == 0 {
-		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("8")).
+		return m.theme.Faint.
 			Render("No tabs found. Press Ctrl+N to create a new tab.")
 	}
 
@@ -72,7 +88,7 @@ func (m TabBrowserModel) View() string {
 		style := lipgloss.NewStyle()
 
 		if i == m.cursor {
-			style = style.Background(lipgloss.Color("12")).Foreground(lipgloss.Color("15"))
+			style = m.theme.Selected
 		}
 
 		// Format: [ID] Name - Artist (Date)
@@ -110,3 +126,12 @@ func (m *TabBrowserModel) SetCursor(cursor int) {
 		m.cursor = cursor
 	}
 }
+
+// RowAt returns the tab listed at a line of the view, such as a mouse click
+func (m TabBrowserModel) RowAt(y int) (int, bool) {
+	row := y + m.viewport.YOffset
+	if y < 0 || y >= m.viewport.Height || row >= len(m.tabs) {
+		return 0, false
+	}
+	return row, true
+}
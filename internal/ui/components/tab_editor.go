@@ -4,12 +4,15 @@ package components
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/Cod-e-Codes/tuitar/internal/models"
+	"github.com/Cod-e-Codes/tuitar/internal/theory"
 )
 
 type HighlightUpdateMsg struct {
@@ -25,7 +28,38 @@ type TabEditorModel struct {
 	changed        bool
 	editMode       models.EditMode
 	highlightedPos []models.Position // For playback highlighting
-	showHelp       bool              // Show measure management help
+	keys           EditorKeyMap
+	theme          Theme
+	laneText       string            // Chord or lyric being typed in a lane mode
+	pendingForm    bool              // R was pressed; the next key edits repeat and navigation markings
+	shiftScope     models.ShiftScope // How far column inserts and deletes shift content
+	anchor         models.Position   // Fixed corner of the selection in select mode
+	dragging       bool              // Left button held after a click on a cell
+	status         string            // Feedback from the last command, shown by the app
+	showAnalysis   bool              // Show the recognized chord of each column above the staff
+	maxStretch     int               // Widest chord span not reported as a playability warning
+	count          int               // Count typed before a command, 0 for none
+	operator       string            // Operator waiting for a motion: d, y or c
+	opCount        int               // Count typed before the operator
+	command        []tea.KeyMsg      // Keys of the normal mode command being typed
+	lastChange     []tea.KeyMsg      // Keys of the last change, replayed by .
+	insertKeys     []tea.KeyMsg      // Keys of the insertion in progress, for .
+	replaying      bool              // Keys are being replayed by .
+	register       models.Clip       // Cells last yanked or deleted
+	pendingReg     rune              // q, @, m or ' waiting for the name of a register or mark
+	recording      rune              // Register a macro is being recorded into, 0 for none
+	macroKeys      []tea.KeyMsg      // Keys recorded so far
+	macros         map[rune][]tea.KeyMsg
+	lastMacro      rune // Register played last, for @@
+	macroDepth     int  // Macros being played, counting macros played by macros
+	macroPlayed    int  // Keys played by the macro being played, nested macros included
+	inUpdate       bool // Keys reach SetEditMode through Update rather than the app
+	searchQuery    string
+	searchTerms    []models.SearchTerm // Terms of the last search, nil before the first
+	searchBackward bool                // The last search was made with ?
+	showMatches    bool                // Highlight the matches of the last search
+	jumps          []models.Position   // Positions left by jumps, oldest first
+	jumpIdx        int                 // Entry Ctrl+O and Ctrl+I moved to, len(jumps) when at the newest
 }
 
 func NewTabEditor(tab *models.Tab) TabEditorModel {
@@ -39,18 +73,33 @@ func NewTabEditor(tab *models.Tab) TabEditorModel {
 	}
 
 	return TabEditorModel{
-		tab:      tab,
-		viewport: vp,
-		cursor:   models.Position{String: 0, Position: 0},
-		editMode: models.EditNormal,
+		tab:        tab,
+		keys:       NewEditorKeyMap(),
+		theme:      DarkTheme(),
+		viewport:   vp,
+		cursor:     models.Position{String: 0, Position: 0},
+		editMode:   models.EditNormal,
+		maxStretch: models.DefaultMaxStretch,
 	}
 }
 
+// SetKeyMap replaces the key bindings, as read from the config file
+func (m *TabEditorModel) SetKeyMap(keys EditorKeyMap) {
+	m.keys = keys
+}
+
+func (m *TabEditorModel) SetTheme(theme Theme) {
+	m.theme = theme
+}
+
 func (m *TabEditorModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 	m.viewport.Width = width
 	m.viewport.Height = height - 4 // Reserve space for headers
+	if m.tab != nil {
+		m.updateViewportForCursor()
+	}
 }
 
 // Updated to set changed = true to force re-render on highlight change
@@ -67,144 +116,617 @@ func (m TabEditorModel) Update(msg tea.Msg) (TabEditorModel, tea.Cmd) {
 		m.changed = true
 		return m, nil
 
+	case tea.MouseMsg:
+		m.status = ""
+		m.updateMouse(msg)
+		return m, nil
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		// Navigation keys work in both modes
-		case "h", "left":
-			if m.cursor.Position > 0 {
-				m.cursor.Position--
+		m.status = ""
+		if m.recordKey(msg) {
+			return m, nil
+		}
+		m.inUpdate = true
+		done := m.updateKey(msg)
+		m.inUpdate = false
+		// Keys move the cursor and change the lanes drawn, so the view
+		// follows the cursor block after each one
+		m.updateViewportForCursor()
+		if done {
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// updateKey runs the command of a key. It returns true when the key is
+// used up, and false to let the viewport see it as well.
+func (m *TabEditorModel) updateKey(msg tea.KeyMsg) bool {
+	if key.Matches(msg, m.keys.Normal) && m.editMode != models.EditNormal {
+		m.SetEditMode(models.EditNormal)
+		return true
+	}
+
+	if m.InTextEntry() {
+		m.updateLane(msg)
+		return true
+	}
+
+	if m.pendingForm {
+		m.pendingForm = false
+		m.updateForm(msg)
+		m.command = append(m.command, msg)
+		m.endCommand(true)
+		return true
+	}
+
+	if m.editMode == models.EditSelect && m.updateSelection(msg) {
+		return true
+	}
+
+	if m.editMode == models.EditInsert && m.insertKeys != nil {
+		m.insertKeys = append(m.insertKeys, msg)
+	}
+
+	if m.editMode == models.EditNormal && m.updateNormal(msg) {
+		return !m.keys.movesOnly(msg)
+	}
+
+	m.handleKey(msg)
+	return false
+}
+
+// handleKey runs the command bound to a single key. Letter keys act in
+// normal mode only, where they are not typed as frets.
+func (m *TabEditorModel) handleKey(msg tea.KeyMsg) {
+	keys := m.keys
+	normal := m.editMode == models.EditNormal
+	maxPos := len(m.tab.Content[m.cursor.String]) - 1
+
+	switch {
+	// Navigation keys work in both modes
+	case key.Matches(msg, keys.Left):
+		if m.cursor.Position > 0 {
+			m.cursor.Position--
+		}
+	case key.Matches(msg, keys.Right):
+		if m.cursor.Position < maxPos {
+			m.cursor.Position++
+		}
+	case key.Matches(msg, keys.Up):
+		if m.cursor.String > 0 {
+			m.cursor.String--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.cursor.String < 5 {
+			m.cursor.String++
+		}
+
+	// Page scrolling
+	case key.Matches(msg, keys.PageUp):
+		// Scroll up by viewport height, taking the cursor along
+		m.scroll(-m.viewport.Height)
+		m.pageCursor(false)
+	case key.Matches(msg, keys.PageDown):
+		m.scroll(m.viewport.Height)
+		m.pageCursor(true)
+
+	// More intuitive cursor movement
+	case key.Matches(msg, keys.NextMeasure):
+		// Move to next word/measure boundary (forward)
+		if m.editMode != models.EditInsert {
+			nextMeasurePos := ((m.cursor.Position / models.MeasureLength) + 1) * models.MeasureLength
+			m.cursor.Position = min(nextMeasurePos, maxPos)
+		}
+	case key.Matches(msg, keys.PrevMeasure):
+		// Move to previous word/measure boundary (backward)
+		if m.editMode != models.EditInsert && m.cursor.Position > 0 {
+			m.cursor.Position = ((m.cursor.Position - 1) / models.MeasureLength) * models.MeasureLength
+		}
+	case key.Matches(msg, keys.MeasureStart):
+		// Move to beginning of current measure (like 'gg' in vim)
+		if m.editMode != models.EditInsert {
+			m.cursor.Position = (m.cursor.Position / models.MeasureLength) * models.MeasureLength
+		}
+	case key.Matches(msg, keys.MeasureEnd):
+		// Move to end of current measure
+		if m.editMode != models.EditInsert {
+			measureEnd := ((m.cursor.Position/models.MeasureLength)+1)*models.MeasureLength - 1
+			m.cursor.Position = min(measureEnd, maxPos)
+		}
+	case key.Matches(msg, keys.NextSection):
+		// Jump to the start of the next section
+		measure := m.cursor.Position / models.MeasureLength
+		for _, section := range m.tab.Sections {
+			if section.Start > measure {
+				m.JumpToMeasure(section.Start)
+				break
+			}
+		}
+	case key.Matches(msg, keys.PrevSection):
+		// Jump to the start of the current section, or the previous one
+		measure := m.cursor.Position / models.MeasureLength
+		atStart := m.cursor.Position%models.MeasureLength == 0
+		for i := len(m.tab.Sections) - 1; i >= 0; i-- {
+			section := m.tab.Sections[i]
+			if section.Start < measure || (section.Start == measure && !atStart) {
+				m.JumpToMeasure(section.Start)
+				break
 			}
-		case "l", "right":
-			maxPos := len(m.tab.Content[m.cursor.String]) - 1
+		}
+
+	// Matches of the last search
+	case key.Matches(msg, keys.NextMatch, keys.PrevMatch):
+		if normal {
+			m.nextMatch(key.Matches(msg, keys.PrevMatch))
+		}
+	case key.Matches(msg, keys.StringStart):
+		m.cursor.Position = 0
+	case key.Matches(msg, keys.StringEnd):
+		m.cursor.Position = maxPos
+
+	// Insert mode specific keys; frets are typed as digits
+	case m.editMode == models.EditInsert && len(msg.Runes) == 1 && unicode.IsDigit(msg.Runes[0]):
+		m.insertCharAt(m.cursor, msg.Runes[0])
+		m.changed = true
+		if m.cursor.Position < maxPos {
+			m.cursor.Position++
+		}
+	case key.Matches(msg, keys.Rest):
+		if m.editMode == models.EditInsert {
+			m.insertCharAt(m.cursor, '-')
+			m.changed = true
 			if m.cursor.Position < maxPos {
 				m.cursor.Position++
 			}
-		case "k", "up":
-			if m.cursor.String > 0 {
-				m.cursor.String--
-			}
-		case "j", "down":
-			if m.cursor.String < 5 {
-				m.cursor.String++
-			}
+		}
 
-		// Page scrolling
-		case "pgup":
-			// Scroll up by viewport height
-			for i := 0; i < m.viewport.Height; i++ {
-				m.viewport.ScrollUp(1)
-			}
-		case "pgdown":
-			// Scroll down by viewport height
-			for i := 0; i < m.viewport.Height; i++ {
-				m.viewport.ScrollDown(1)
-			}
+	// Delete key works in normal mode
+	case key.Matches(msg, keys.DeleteFret):
+		if normal {
+			m.deleteCharAt(m.cursor)
+			m.changed = true
+		}
 
-		// More intuitive cursor movement
-		case "w":
-			// Move to next word/measure boundary (forward)
-			if m.editMode == models.EditNormal {
-				// Move to next measure boundary
-				nextMeasurePos := ((m.cursor.Position / models.MeasureLength) + 1) * models.MeasureLength
-				maxPos := len(m.tab.Content[m.cursor.String]) - 1
-				if nextMeasurePos <= maxPos {
-					m.cursor.Position = nextMeasurePos
-				} else {
-					m.cursor.Position = maxPos
-				}
-			}
-		case "b":
-			// Move to previous word/measure boundary (backward)
-			if m.editMode == models.EditNormal {
-				// Move to previous measure boundary
-				if m.cursor.Position > 0 {
-					prevMeasurePos := ((m.cursor.Position - 1) / models.MeasureLength) * models.MeasureLength
-					m.cursor.Position = prevMeasurePos
-				}
-			}
-		case "g":
-			// Move to beginning of current measure (like 'gg' in vim)
-			if m.editMode == models.EditNormal {
-				measureStart := (m.cursor.Position / models.MeasureLength) * models.MeasureLength
-				m.cursor.Position = measureStart
-			}
-		case "$":
-			// Move to end of current measure
-			if m.editMode == models.EditNormal {
-				measureEnd := ((m.cursor.Position/models.MeasureLength)+1)*models.MeasureLength - 1
-				maxPos := len(m.tab.Content[m.cursor.String]) - 1
-				if measureEnd <= maxPos {
-					m.cursor.Position = measureEnd
-				} else {
-					m.cursor.Position = maxPos
-				}
-			}
-		case "home":
-			m.cursor.Position = 0
-		case "end":
-			m.cursor.Position = len(m.tab.Content[m.cursor.String]) - 1
-
-		// Insert mode specific keys
-		case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
-			if m.editMode == models.EditInsert {
-				m.insertCharAt(m.cursor, rune(msg.String()[0]))
+	// Backspace works in insert mode
+	case key.Matches(msg, keys.Backspace):
+		if m.editMode == models.EditInsert && m.cursor.Position > 0 {
+			m.cursor.Position--
+			m.deleteCharAt(m.cursor)
+			m.changed = true
+		}
+
+	case key.Matches(msg, keys.Insert):
+		if normal || m.editMode == models.EditSelect {
+			m.SetEditMode(models.EditInsert)
+		}
+
+	// M adds a measure at the end; m sets marks, and :measure remove
+	// takes the last measure off
+	case key.Matches(msg, keys.AddMeasure):
+		if normal {
+			m.tab.AddMeasure()
+			m.changed = true
+		}
+
+	// Jump list; terminals send Ctrl+I as Tab
+	case key.Matches(msg, keys.JumpOlder):
+		if normal {
+			m.jumpOlder()
+		}
+	case key.Matches(msg, keys.JumpNewer):
+		if normal {
+			m.jumpNewer()
+		}
+
+	// Column insert/delete shift the following columns; named keys such
+	// as Insert and Delete work in insert mode too
+	case key.Matches(msg, keys.InsertColumn):
+		if normal || msg.Type != tea.KeyRunes {
+			if m.tab.InsertColumn(m.cursor.Position, m.shiftScope) {
 				m.changed = true
-				if m.cursor.Position < len(m.tab.Content[m.cursor.String])-1 {
-					m.cursor.Position++
-				}
+			} else {
+				m.status = "The shift would split a two-digit fret"
 			}
-		case "-":
-			if m.editMode == models.EditInsert {
-				m.insertCharAt(m.cursor, '-')
+		}
+	case key.Matches(msg, keys.DeleteColumn):
+		if normal || msg.Type != tea.KeyRunes {
+			if m.tab.DeleteColumn(m.cursor.Position, m.shiftScope) {
 				m.changed = true
-				if m.cursor.Position < len(m.tab.Content[m.cursor.String])-1 {
-					m.cursor.Position++
-				}
+			} else {
+				m.status = "The shift would split a two-digit fret"
 			}
-
-		// Delete key works in normal mode
-		case "x":
-			if m.editMode == models.EditNormal {
-				m.deleteCharAt(m.cursor)
-				m.changed = true
+		}
+	case key.Matches(msg, keys.ShiftScope):
+		if normal {
+			if m.shiftScope == models.ShiftMeasure {
+				m.shiftScope = models.ShiftTab
+			} else {
+				m.shiftScope = models.ShiftMeasure
 			}
+			m.changed = true
+		}
 
-		// Backspace works in insert mode
-		case "backspace", "ctrl+h":
-			if m.editMode == models.EditInsert && m.cursor.Position > 0 {
-				m.cursor.Position--
-				m.deleteCharAt(m.cursor)
-				m.changed = true
-			}
+	// Restructuring at the cursor measure
+	case key.Matches(msg, keys.InsertMeasure, keys.AppendMeasure, keys.DeleteMeasure,
+		keys.DuplicateMeasure, keys.MoveMeasureLeft, keys.MoveMeasureRight):
+		if normal {
+			m.editMeasure(m.measureOp(msg))
+		}
 
-		// Measure management keys (work in normal mode)
-		// Use 'm' for add measure and 'M' for remove measure (simpler than Ctrl+M which conflicts with Enter)
-		case "m":
-			if m.editMode == models.EditNormal {
-				m.tab.AddMeasure()
-				m.changed = true
-			}
-		case "M":
-			if m.editMode == models.EditNormal {
-				m.tab.RemoveMeasure()
-				// Adjust cursor position if it's beyond the new length
-				maxPos := m.tab.GetTotalLength() - 1
-				if m.cursor.Position > maxPos {
-					m.cursor.Position = maxPos
-				}
-				m.changed = true
+	// Chord and lyric lanes have their own text entry modes
+	case key.Matches(msg, keys.ChordLane):
+		if normal {
+			m.SetEditMode(models.EditChord)
+		}
+	case key.Matches(msg, keys.LyricLane):
+		if normal {
+			m.SetEditMode(models.EditLyric)
+		}
+
+	// Select a block of strings and columns
+	case key.Matches(msg, keys.Select):
+		switch m.editMode {
+		case models.EditNormal:
+			m.SetEditMode(models.EditSelect)
+		case models.EditSelect:
+			m.SetEditMode(models.EditNormal)
+		}
+
+	// Recognized chord names above the staff
+	case key.Matches(msg, keys.Analysis):
+		if normal {
+			m.showAnalysis = !m.showAnalysis
+			m.status = "Chord recognition hidden"
+			if m.showAnalysis {
+				m.status = "Chord recognition shown"
 			}
-		case "?":
-			if m.editMode == models.EditNormal {
-				m.showHelp = !m.showHelp
-				m.changed = true
+			m.changed = true
+		}
+
+	// Repeats and navigation markings take a second key
+	case key.Matches(msg, keys.Form):
+		if normal {
+			m.pendingForm = true
+		}
+	}
+}
+
+// measureOp names the measure command bound to the key, as taken by
+// editMeasure
+func (m TabEditorModel) measureOp(msg tea.KeyMsg) string {
+	keys := m.keys
+	switch {
+	case key.Matches(msg, keys.InsertMeasure):
+		return "insert"
+	case key.Matches(msg, keys.AppendMeasure):
+		return "append"
+	case key.Matches(msg, keys.DeleteMeasure):
+		return "delete"
+	case key.Matches(msg, keys.DuplicateMeasure):
+		return "duplicate"
+	case key.Matches(msg, keys.MoveMeasureLeft):
+		return "left"
+	}
+	return "right"
+}
+
+// updateLane handles typing in the chord and lyric lanes. Letters are text
+// here, so only the arrow keys navigate.
+func (m *TabEditorModel) updateLane(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "left":
+		if m.cursor.Position > 0 {
+			m.commitLane()
+			m.cursor.Position--
+			m.loadLane()
+		}
+	case "right":
+		if m.cursor.Position < m.tab.GetTotalLength()-1 {
+			m.commitLane()
+			m.cursor.Position++
+			m.loadLane()
+		}
+	case "up", "down":
+		// Lanes span all strings; nothing to do
+	case "enter":
+		m.commitLane()
+	case " ":
+		// Commit and jump to the next column holding a note, which is
+		// where the next chord change or syllable usually goes
+		m.commitLane()
+		m.cursor.Position = m.nextNoteColumn(m.cursor.Position)
+		m.loadLane()
+	case "backspace", "ctrl+h":
+		if runes := []rune(m.laneText); len(runes) > 0 {
+			m.laneText = string(runes[:len(runes)-1])
+		}
+	default:
+		if msg.Type == tea.KeyRunes {
+			m.laneText += string(msg.Runes)
+		}
+	}
+	m.changed = true
+}
+
+// editMeasure inserts, deletes, duplicates or moves (left, right) the
+// measure under the cursor. The cursor follows the affected measure,
+// keeping its column.
+func (m *TabEditorModel) editMeasure(op string) {
+	measure := m.cursor.Position / models.MeasureLength
+	column := m.cursor.Position % models.MeasureLength
+	target := measure
+
+	switch op {
+	case "insert":
+		m.tab.InsertMeasure(measure)
+	case "append":
+		m.tab.InsertMeasure(measure + 1)
+		target++
+	case "delete":
+		m.tab.DeleteMeasure(measure)
+	case "duplicate":
+		m.tab.DuplicateMeasure(measure)
+		target++
+	case "left":
+		if measure == 0 {
+			return
+		}
+		m.tab.MoveMeasure(measure, measure-1)
+		target--
+	case "right":
+		if measure >= m.tab.GetMeasureCount()-1 {
+			return
+		}
+		m.tab.MoveMeasure(measure, measure+1)
+		target++
+	}
+
+	if target >= m.tab.GetMeasureCount() {
+		target = m.tab.GetMeasureCount() - 1
+	}
+	m.cursor.Position = target*models.MeasureLength + column
+	m.changed = true
+}
+
+// EditMeasures applies a measure command count times: "add" and "remove"
+// append and take off measures at the end, "insert" and "append" add
+// before and after the cursor measure, and "delete" and "duplicate" act on
+// the cursor measure
+func (m *TabEditorModel) EditMeasures(op string, count int) {
+	before := m.tab.GetMeasureCount()
+	for i := 0; i < count; i++ {
+		switch op {
+		case "add":
+			m.tab.AddMeasure()
+			m.changed = true
+		case "remove":
+			m.tab.RemoveMeasure()
+			m.cursor.Position = min(m.cursor.Position, m.tab.GetTotalLength()-1)
+			m.changed = true
+		case "insert", "append", "delete", "duplicate":
+			m.editMeasure(op)
+		default:
+			m.status = "Unknown measure command: " + op
+			return
+		}
+	}
+
+	after := m.tab.GetMeasureCount()
+	if after >= before {
+		m.status = fmt.Sprintf("Added %d measure(s), %d in total", after-before, after)
+	} else {
+		m.status = fmt.Sprintf("Deleted %d measure(s), %d left", before-after, after)
+	}
+}
+
+// updateSelection handles the commands acting on the selection. It
+// returns false for keys that should move the cursor as usual.
+func (m *TabEditorModel) updateSelection(msg tea.KeyMsg) bool {
+	keys := m.keys
+	switch {
+	case key.Matches(msg, keys.SemitoneUp):
+		m.Transpose(1)
+	case key.Matches(msg, keys.SemitoneDown):
+		m.Transpose(-1)
+	case key.Matches(msg, keys.OctaveUp):
+		m.Transpose(12)
+	case key.Matches(msg, keys.OctaveDown):
+		m.Transpose(-12)
+	case key.Matches(msg, keys.StringBelow):
+		m.moveToString(1)
+	case key.Matches(msg, keys.StringAbove):
+		m.moveToString(-1)
+	case key.Matches(msg, keys.Yank):
+		m.register = m.tab.Yank(m.Selection())
+		m.status = fmt.Sprintf("Yanked %d string(s) × %d column(s)", len(m.register.Lines), m.register.Width())
+		m.SetEditMode(models.EditNormal)
+	case key.Matches(msg, keys.Delete, keys.DeleteFret):
+		m.register = m.tab.Yank(m.Selection())
+		m.tab.ClearBlock(m.Selection())
+		m.changed = true
+		m.SetEditMode(models.EditNormal)
+	default:
+		return false
+	}
+	return true
+}
+
+// Selection returns the block between the anchor and the cursor in select
+// mode, or the whole tab otherwise
+func (m TabEditorModel) Selection() models.Selection {
+	if m.editMode != models.EditSelect {
+		return m.tab.WholeTab()
+	}
+	return models.Selection{
+		FirstString: min(m.anchor.String, m.cursor.String),
+		LastString:  max(m.anchor.String, m.cursor.String),
+		Start:       min(m.anchor.Position, m.cursor.Position),
+		End:         max(m.anchor.Position, m.cursor.Position),
+	}
+}
+
+// Transpose shifts the selected notes, or the whole tab outside select
+// mode, by the given number of semitones
+func (m *TabEditorModel) Transpose(semitones int) {
+	sel := m.Selection()
+	count := len(m.tab.SelectedNotes(sel))
+	failed := m.tab.Transpose(sel, semitones)
+	m.changed = true
+
+	m.status = fmt.Sprintf("Transposed %d note(s) by %+d semitone(s)", count-failed, semitones)
+	if failed > 0 {
+		m.status += fmt.Sprintf("; %d did not fit on the fretboard", failed)
+	}
+}
+
+// StampVoicing writes a chord shape into the cursor column across all
+// strings, replacing the notes that were there
+func (m *TabEditorModel) StampVoicing(name string, frets [6]int) {
+	failed := m.tab.SetColumn(m.cursor.Position, frets)
+	m.changed = true
+
+	m.status = "Inserted " + name
+	if failed > 0 {
+		m.status += fmt.Sprintf("; %d string(s) did not fit next to other notes", failed)
+	}
+}
+
+// moveToString re-fingers the selected notes delta strings down (towards
+// the low E) or up, keeping their pitch. The selection follows the notes.
+func (m *TabEditorModel) moveToString(delta int) {
+	sel := m.Selection()
+	if sel.FirstString+delta < 0 || sel.LastString+delta > 5 {
+		return
+	}
+	count := len(m.tab.SelectedNotes(sel))
+	failed := m.tab.MoveToString(sel, delta)
+	m.changed = true
+
+	m.anchor.String += delta
+	m.cursor.String += delta
+	if failed > 0 {
+		m.status = fmt.Sprintf("%d of %d note(s) cannot be played on that string", failed, count)
+	}
+}
+
+// Status returns feedback from the last command, if any
+func (m TabEditorModel) Status() string {
+	return m.status
+}
+
+// updateForm applies the key following R to the markings of the measure
+// under the cursor. Unknown keys cancel.
+func (m *TabEditorModel) updateForm(msg tea.KeyMsg) {
+	measure := m.cursor.Position / models.MeasureLength
+	form := m.tab.FormAt(measure)
+
+	switch key := msg.String(); key {
+	case "[":
+		form.RepeatStart = !form.RepeatStart
+	case "]":
+		// Cycle the number of plays: none, 2, 3, 4
+		switch {
+		case form.RepeatEnd == 0:
+			form.RepeatEnd = 2
+		case form.RepeatEnd >= 4:
+			form.RepeatEnd = 0
+		default:
+			form.RepeatEnd++
+		}
+	case "1", "2", "3", "4":
+		ending := int(key[0] - '0')
+		if form.Ending == ending {
+			ending = 0
+		}
+		form.Ending = ending
+	case "0":
+		form.Ending = 0
+	case "s":
+		form.Segno = !form.Segno
+	case "c":
+		form.Coda = !form.Coda
+	case "t":
+		form.ToCoda = !form.ToCoda
+	case "f":
+		form.Fine = !form.Fine
+	case "j":
+		next := 0
+		for i, jump := range models.Jumps {
+			if jump == form.Jump {
+				next = (i + 1) % len(models.Jumps)
 			}
 		}
+		form.Jump = models.Jumps[next]
+	case "x":
+		form = models.MeasureForm{Measure: measure}
+	default:
+		return
 	}
 
-	var cmd tea.Cmd
-	m.viewport, cmd = m.viewport.Update(msg)
-	return m, cmd
+	m.tab.SetForm(form)
+	m.changed = true
+}
+
+// PendingKey reports whether the editor is waiting for more keys of a
+// command: the key after R, a motion after an operator, or the command a
+// count applies to
+func (m TabEditorModel) PendingKey() bool {
+	return m.pendingForm || m.operator != "" || m.count > 0 || m.pendingReg != 0
+}
+
+// PendingForm reports whether the key after R is awaited
+func (m TabEditorModel) PendingForm() bool {
+	return m.pendingForm
+}
+
+// nextNoteColumn returns the next column after pos that starts a note,
+// or the following column if there are no more notes
+func (m TabEditorModel) nextNoteColumn(pos int) int {
+	for _, note := range m.tab.Notes() {
+		if note.Position > pos {
+			return note.Position
+		}
+	}
+	if pos < m.tab.GetTotalLength()-1 {
+		return pos + 1
+	}
+	return pos
+}
+
+// loadLane starts editing the annotation under the cursor
+func (m *TabEditorModel) loadLane() {
+	switch m.editMode {
+	case models.EditChord:
+		m.laneText = m.tab.ChordAt(m.cursor.Position)
+	case models.EditLyric:
+		m.laneText = m.tab.LyricAt(m.cursor.Position)
+	}
+}
+
+// commitLane stores the text being typed at the cursor column
+func (m *TabEditorModel) commitLane() {
+	text := strings.TrimSpace(m.laneText)
+	switch m.editMode {
+	case models.EditChord:
+		if text != m.tab.ChordAt(m.cursor.Position) {
+			m.tab.SetChord(m.cursor.Position, text)
+			m.changed = true
+		}
+	case models.EditLyric:
+		if text != m.tab.LyricAt(m.cursor.Position) {
+			m.tab.SetLyric(m.cursor.Position, text)
+			m.changed = true
+		}
+	}
+}
+
+// InTextEntry reports whether keys are being typed into a chord or lyric lane
+func (m TabEditorModel) InTextEntry() bool {
+	return m.editMode == models.EditChord || m.editMode == models.EditLyric
 }
 
 func (m *TabEditorModel) insertCharAt(pos models.Position, char rune) {
@@ -226,8 +748,8 @@ func (m *TabEditorModel) deleteCharAt(pos models.Position) {
 func (m TabEditorModel) View() string {
 	var lines []string
 
-	// String labels (high to low pitch, matching guitar orientation)
-	stringLabels := []string{"e", "B", "G", "D", "A", "E"}
+	// String labels from the tuning (high to low pitch, matching guitar orientation)
+	stringLabels := m.tab.StringLabels()
 
 	// Helper to check if position is highlighted
 	isHighlighted := func(str, pos int) bool {
@@ -239,55 +761,75 @@ func (m TabEditorModel) View() string {
 		return false
 	}
 
+	matches := m.searchMatches()
+	isMatch := func(str, pos int) bool {
+		for _, match := range matches {
+			if pos >= match.Start && pos <= match.End && match.Contains(str, pos) {
+				return true
+			}
+		}
+		return false
+	}
+
 	// Helper to check if position is at a measure boundary
 	isMeasureBoundary := func(pos int) bool {
 		return pos > 0 && pos%models.MeasureLength == 0
 	}
 
-	// Calculate how many measures can fit on one line
-	// Use a reasonable default width if width is 0 (not set yet)
-	displayWidth := m.width
-	if displayWidth == 0 {
-		displayWidth = 120 // Default terminal width
-	}
+	warnings := m.tab.Playability(m.maxStretch)
 
-	// Account for string labels (3 chars) + pipe (1 char) + pipe at end (1 char) = 5 chars
-	availableWidth := displayWidth - 5
-	measuresPerLine := availableWidth / (models.MeasureLength + 1) // +1 for spacing between measures
-	if measuresPerLine < 1 {
-		measuresPerLine = 1
-	}
-
-	// Debug: let's be more generous with side-by-side display
-	// Try to fit at least 2-3 measures side by side if possible
-	if availableWidth >= (models.MeasureLength*2)+2 {
-		measuresPerLine = 2
-	}
-	if availableWidth >= (models.MeasureLength*3)+3 {
-		measuresPerLine = 3
-	}
-	if availableWidth >= (models.MeasureLength*4)+4 {
-		measuresPerLine = 4
+	var analysis []models.Annotation
+	if m.showAnalysis {
+		analysis = theory.ColumnChords(m.tab)
 	}
 
 	// Render measures in blocks
-	for measureStart := 0; measureStart < m.tab.GetMeasureCount(); measureStart += measuresPerLine {
+	for _, block := range m.blocks(warnings) {
+		measureStart, measuresInBlock := block.Start, block.Count
+
 		// Add spacing between measure blocks (except for the first one)
 		if measureStart > 0 {
 			lines = append(lines, "")
 		}
 
-		// Determine how many measures to render in this block
-		measuresInBlock := measuresPerLine
-		if measureStart+measuresInBlock > m.tab.GetMeasureCount() {
-			measuresInBlock = m.tab.GetMeasureCount() - measureStart
+		if len(m.tab.Sections) > 0 {
+			lines = append(lines, m.renderSectionLine(measureStart, measuresInBlock))
+		}
+
+		if m.tab.HasForm() {
+			lines = append(lines, m.renderFormLine(measureStart, measuresInBlock))
+		}
+
+		if len(m.tab.Chords) > 0 || m.editMode == models.EditChord {
+			lines = append(lines, m.renderLane(m.tab.Chords, measureStart, measuresInBlock, models.EditChord))
+		}
+
+		if m.showAnalysis {
+			lines = append(lines, m.renderLane(analysis, measureStart, measuresInBlock, models.EditNormal))
+		}
+
+		// Strings with a playability warning in the block get a red label
+		blockStart := measureStart * models.MeasureLength
+		blockEnd := blockStart + measuresInBlock*models.MeasureLength
+		var warned [6]bool
+		var warnedColumns []int
+		for _, w := range warnings {
+			if w.Position < blockStart || w.Position >= blockEnd {
+				continue
+			}
+			if w.String >= 0 {
+				warned[w.String] = true
+			}
+			warnedColumns = append(warnedColumns, w.Position)
 		}
 
 		// Render each string for this block of measures
 		for i, label := range stringLabels {
-			line := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("14")).
-				Render(label + "|")
+			labelStyle := m.theme.Label
+			if warned[i] {
+				labelStyle = m.theme.Warning
+			}
+			line := labelStyle.Render(label + "|")
 
 			// Render each measure in this block
 			for measureIdx := 0; measureIdx < measuresInBlock; measureIdx++ {
@@ -316,16 +858,20 @@ func (m TabEditorModel) View() string {
 					case m.cursor.String == i && m.cursor.Position == pos:
 						// Highlight cursor position (takes precedence)
 						if m.editMode == models.EditInsert {
-							style = style.Background(lipgloss.Color("11")).Foreground(lipgloss.Color("0"))
+							style = m.theme.Insert
 						} else {
-							style = style.Background(lipgloss.Color("12")).Foreground(lipgloss.Color("15"))
+							style = m.theme.Selected
 						}
+					case m.editMode == models.EditSelect && m.Selection().Contains(i, pos):
+						style = m.theme.Selection
 					case isHighlighted(i, pos):
-						// Highlight playback positions with cyan background
-						style = style.Background(lipgloss.Color("37")).Foreground(lipgloss.Color("0"))
+						// Highlight playback positions
+						style = m.theme.Playing
+					case isMatch(i, pos):
+						style = m.theme.Match
 					case isMeasureBoundary(pos % models.MeasureLength):
 						// Add subtle highlighting for measure boundaries
-						style = style.Foreground(lipgloss.Color("8"))
+						style = m.theme.Faint
 					}
 
 					line += style.Render(string(char))
@@ -337,15 +883,21 @@ func (m TabEditorModel) View() string {
 				}
 			}
 
-			line += lipgloss.NewStyle().
-				Foreground(lipgloss.Color("14")).
-				Render("|")
+			line += m.theme.Label.Render("|")
 
 			lines = append(lines, line)
 		}
 
+		if len(warnedColumns) > 0 {
+			lines = append(lines, m.renderWarningLine(warnedColumns, measureStart, measuresInBlock))
+		}
+
+		if len(m.tab.Lyrics) > 0 || m.editMode == models.EditLyric {
+			lines = append(lines, m.renderLane(m.tab.Lyrics, measureStart, measuresInBlock, models.EditLyric))
+		}
+
 		// Add measure numbers below this block
-		measureLine := "   "
+		measureLine := m.gutter() + " "
 		for measureIdx := 0; measureIdx < measuresInBlock; measureIdx++ {
 			actualMeasureIdx := measureStart + measureIdx
 			measureNum := fmt.Sprintf("%d", actualMeasureIdx+1)
@@ -361,57 +913,541 @@ func (m TabEditorModel) View() string {
 		lines = append(lines, measureLine)
 	}
 
-	// Add help information if requested
-	if m.showHelp {
-		helpLines := []string{
-			"",
-			"Measure Management:",
-			"  m                   - Add a new measure",
-			"  M                   - Remove last measure",
-			"  ?                   - Toggle this help",
-			"",
-			"Navigation:",
-			"  h/j/k/l    - Move cursor (left/down/up/right)",
-			"  w/b        - Move to next/previous measure",
-			"  g/$        - Move to start/end of measure",
-			"  Home/End   - Move to start/end of string",
-			"  PgUp/PgDn  - Page up/down scrolling",
-			"",
-			"Editing:",
-			"  i          - Enter insert mode",
-			"  Esc        - Exit insert mode",
-			"  x          - Delete character (normal mode)",
-			"  Backspace  - Delete character (insert mode)",
-		}
-		lines = append(lines, helpLines...)
-	}
-
 	content := strings.Join(lines, "\n")
 	m.viewport.SetContent(content)
 
-	// Update viewport to follow cursor if needed
+	return m.viewport.View()
+}
+
+// renderLane draws a chord or lyric lane for one block of measures, with
+// each text starting above/below its anchor column. Texts that would overlap
+// are pushed right so they stay readable. With EditNormal as the mode the
+// lane is read-only, as used for recognized chord names.
+func (m TabEditorModel) renderLane(lane []models.Annotation, measureStart, measuresInBlock int, mode models.EditMode) string {
+	blockStart := measureStart * models.MeasureLength
+	blockEnd := blockStart + measuresInBlock*models.MeasureLength
+	width := measuresInBlock*(models.MeasureLength+1) - 1
+
+	// Offset of a tab column within the lane, accounting for measure spacing
+	offset := func(pos int) int {
+		rel := pos - blockStart
+		return rel + rel/models.MeasureLength
+	}
+
+	cells := []rune(strings.Repeat(" ", width))
+	active := make([]bool, width)
+
+	texts := lane
+	editing := mode != models.EditNormal && m.editMode == mode && m.cursor.Position >= blockStart && m.cursor.Position < blockEnd
+	if editing {
+		texts = nil
+		for _, a := range lane {
+			if a.Position != m.cursor.Position {
+				texts = append(texts, a)
+			}
+		}
+	}
+
+	nextFree := 0
+	for _, a := range texts {
+		if a.Position < blockStart || a.Position >= blockEnd {
+			continue
+		}
+		if editing && a.Position > m.cursor.Position && nextFree <= offset(m.cursor.Position) {
+			nextFree = offset(m.cursor.Position) + len([]rune(m.laneText)) + 1
+		}
+		start := offset(a.Position)
+		if start < nextFree {
+			start = nextFree
+		}
+		for i, r := range []rune(a.Text) {
+			if start+i < width {
+				cells[start+i] = r
+			}
+		}
+		nextFree = start + len([]rune(a.Text)) + 1
+	}
+
+	if editing {
+		start := offset(m.cursor.Position)
+		text := []rune(m.laneText + " ") // Trailing cell shows where typing continues
+		for i, r := range text {
+			if start+i < width {
+				cells[start+i] = r
+				active[start+i] = true
+			}
+		}
+	}
+
+	textStyle := lipgloss.NewStyle()
+	switch mode {
+	case models.EditChord:
+		textStyle = m.theme.Chord
+	case models.EditNormal:
+		textStyle = m.theme.Analysis
+	}
+	activeStyle := m.theme.LaneCursor
+
+	line := m.gutter()
+	for i := 0; i < width; {
+		j := i
+		for j < width && active[j] == active[i] {
+			j++
+		}
+		if active[i] {
+			line += activeStyle.Render(string(cells[i:j]))
+		} else {
+			line += textStyle.Render(string(cells[i:j]))
+		}
+		i = j
+	}
+	return line
+}
+
+// gutter returns blanks as wide as the string labels and the bar line
+// after them, to line the lanes up with the staff
+func (m TabEditorModel) gutter() string {
+	return strings.Repeat(" ", len(m.tab.StringLabels()[0])+1)
+}
+
+// measureBlock is a row of measures drawn side by side in the editor
+type measureBlock struct {
+	Start, Count int // First measure and number of measures
+	Line         int // First line of the block in the editor content
+	Top          int // Line of the highest string
+	Height       int // Lines drawn for the block, the blank line before it included
+}
+
+// measuresPerLine returns how many measures fit side by side
+func (m TabEditorModel) measuresPerLine() int {
+	// Use a reasonable default width if width is 0 (not set yet)
+	displayWidth := m.width
+	if displayWidth == 0 {
+		displayWidth = 120 // Default terminal width
+	}
+
+	// Account for string labels (3 chars) + pipe (1 char) + pipe at end (1 char) = 5 chars
+	availableWidth := displayWidth - 5
+	measuresPerLine := availableWidth / (models.MeasureLength + 1) // +1 for spacing between measures
+	if measuresPerLine < 1 {
+		measuresPerLine = 1
+	}
+
+	// Debug: let's be more generous with side-by-side display
+	// Try to fit at least 2-3 measures side by side if possible
+	if availableWidth >= (models.MeasureLength*2)+2 {
+		measuresPerLine = 2
+	}
+	if availableWidth >= (models.MeasureLength*3)+3 {
+		measuresPerLine = 3
+	}
+	if availableWidth >= (models.MeasureLength*4)+4 {
+		measuresPerLine = 4
+	}
+	return measuresPerLine
+}
+
+// blocks lays out the rows of measures the way View draws them. A block
+// gets a line of markers under its strings when a warning falls in it.
+func (m TabEditorModel) blocks(warnings []models.Warning) []measureBlock {
+	perLine := m.measuresPerLine()
+	count := m.tab.GetMeasureCount()
+
+	// Lanes drawn above and below the strings of every block
+	above, below := 0, 1 // Measure numbers
+	if len(m.tab.Sections) > 0 {
+		above++
+	}
+	if m.tab.HasForm() {
+		above++
+	}
+	if len(m.tab.Chords) > 0 || m.editMode == models.EditChord {
+		above++
+	}
+	if m.showAnalysis {
+		above++
+	}
+	if len(m.tab.Lyrics) > 0 || m.editMode == models.EditLyric {
+		below++
+	}
+
+	var blocks []measureBlock
+	line := 0
+	for start := 0; start < count; start += perLine {
+		block := measureBlock{Start: start, Count: min(perLine, count-start)}
+		blockStart := start * models.MeasureLength
+		blockEnd := blockStart + block.Count*models.MeasureLength
+		warned := 0
+		for _, w := range warnings {
+			if w.Position >= blockStart && w.Position < blockEnd {
+				warned = 1
+				break
+			}
+		}
+
+		block.Line = line
+		block.Height = above + 6 + warned + below
+		if start > 0 {
+			block.Height++ // Blank line between blocks
+		}
+		block.Top = line + block.Height - 6 - warned - below
+		line += block.Height
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// cellAt returns the cell drawn at a point of the editor view, such as a
+// mouse click
+func (m TabEditorModel) cellAt(x, y int) (models.Position, bool) {
+	line := y + m.viewport.YOffset
+	x -= len(m.gutter()) // String label and bar line
+	if x < 0 {
+		return models.Position{}, false
+	}
+	measure, column := x/(models.MeasureLength+1), x%(models.MeasureLength+1)
+	if column == models.MeasureLength {
+		return models.Position{}, false // Space between measures
+	}
+
+	for _, block := range m.blocks(m.tab.Playability(m.maxStretch)) {
+		str := line - block.Top
+		if str < 0 || str >= 6 || measure >= block.Count {
+			continue
+		}
+		return models.Position{String: str, Position: (block.Start+measure)*models.MeasureLength + column}, true
+	}
+	return models.Position{}, false
+}
+
+// updateMouse moves the cursor to a clicked cell, selects the cells a
+// drag goes over and scrolls with the wheel. Coordinates are relative to
+// the editor view.
+func (m *TabEditorModel) updateMouse(msg tea.MouseMsg) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scroll(-m.viewport.MouseWheelDelta)
+		return
+	case tea.MouseButtonWheelDown:
+		m.scroll(m.viewport.MouseWheelDelta)
+		return
+	case tea.MouseButtonLeft:
+	default:
+		return
+	}
+
+	// Lanes take typed text and two-key commands their second key
+	if m.InTextEntry() || m.PendingKey() {
+		return
+	}
+
+	pos, ok := m.cellAt(msg.X, msg.Y)
+	switch msg.Action {
+	case tea.MouseActionPress:
+		m.dragging = ok
+		if !ok {
+			return
+		}
+		// A click ends a selection, as Esc would
+		if m.editMode == models.EditSelect {
+			m.SetEditMode(models.EditNormal)
+		}
+		m.cursor = pos
+	case tea.MouseActionMotion:
+		if !m.dragging || !ok || pos == m.cursor {
+			return
+		}
+		// Dragging selects from the cell first pressed
+		if m.editMode != models.EditSelect {
+			m.SetEditMode(models.EditSelect)
+		}
+		m.cursor = pos
+	case tea.MouseActionRelease:
+		m.dragging = false
+		return
+	}
+	m.changed = true
+}
+
+// renderWarningLine marks the columns of a block that have playability
+// warnings, under the staff
+func (m TabEditorModel) renderWarningLine(columns []int, measureStart, measuresInBlock int) string {
+	blockStart := measureStart * models.MeasureLength
+	cells := []rune(strings.Repeat(" ", measuresInBlock*(models.MeasureLength+1)-1))
+	for _, pos := range columns {
+		rel := pos - blockStart
+		cells[rel+rel/models.MeasureLength] = '▲'
+	}
+	return m.gutter() + m.theme.Warning.Bold(true).
+		Render(strings.TrimRight(string(cells), " "))
+}
+
+// renderSectionLine labels the measures of a block where sections start,
+// with rehearsal letters. A block starting mid-section repeats its name faintly.
+func (m TabEditorModel) renderSectionLine(measureStart, measuresInBlock int) string {
+	labelStyle := m.theme.Section
+	contStyle := m.theme.Faint
+
+	line := m.gutter()
+	width := 0
+	blockWidth := measuresInBlock*(models.MeasureLength+1) - 1
+	for measureIdx := 0; measureIdx < measuresInBlock; measureIdx++ {
+		measure := measureStart + measureIdx
+		target := measureIdx * (models.MeasureLength + 1)
+		if width > target {
+			continue // Previous label runs over this measure
+		}
+
+		idx := m.tab.SectionAt(measure)
+		if idx == -1 {
+			continue
+		}
+
+		var label string
+		style := labelStyle
+		switch {
+		case m.tab.Sections[idx].Start == measure:
+			label = fmt.Sprintf("[%s] %s", models.RehearsalLetter(idx), m.tab.Sections[idx].Name)
+		case measureIdx == 0:
+			label = fmt.Sprintf("(%s)", m.tab.Sections[idx].Name)
+			style = contStyle
+		default:
+			continue
+		}
+
+		if runes := []rune(label); target+len(runes) > blockWidth {
+			label = string(runes[:blockWidth-target])
+		}
+		line += strings.Repeat(" ", target-width) + style.Render(label)
+		width = target + len([]rune(label)) + 1
+		line += " "
+	}
+	return line
+}
+
+// renderFormLine draws the repeat and navigation markings of a block.
+// Markings at the start of a measure are written left, those at its end
+// right, and voltas extend over their measures.
+func (m TabEditorModel) renderFormLine(measureStart, measuresInBlock int) string {
+	style := m.theme.Form
+
+	line := m.gutter()
+	for measureIdx := 0; measureIdx < measuresInBlock; measureIdx++ {
+		measure := measureStart + measureIdx
+		form := m.tab.FormAt(measure)
+
+		var left, right []string
+		if form.RepeatStart {
+			left = append(left, "‖:")
+		}
+		if form.Ending > 0 && (measure == 0 || m.tab.FormAt(measure-1).Ending != form.Ending || measureIdx == 0) {
+			left = append(left, fmt.Sprintf("┌%d.", form.Ending))
+		}
+		if form.Segno {
+			left = append(left, "§")
+		}
+		if form.Coda {
+			left = append(left, "⊕")
+		}
+		if form.ToCoda {
+			right = append(right, "To ⊕")
+		}
+		if form.Fine {
+			right = append(right, "Fine")
+		}
+		if form.Jump != models.JumpNone {
+			right = append(right, string(form.Jump))
+		}
+		if form.RepeatEnd > 0 {
+			end := ":‖"
+			if form.RepeatEnd > 2 {
+				end += fmt.Sprintf("x%d", form.RepeatEnd)
+			}
+			right = append(right, end)
+		}
+
+		leftText := []rune(strings.Join(left, " "))
+		rightText := []rune(strings.Join(right, " "))
+		if len(rightText) > models.MeasureLength {
+			rightText = rightText[len(rightText)-models.MeasureLength:]
+		}
+		if len(leftText)+len(rightText) >= models.MeasureLength {
+			keep := models.MeasureLength - len(rightText) - 1
+			if keep < 0 {
+				keep = 0
+			}
+			leftText = leftText[:min(keep, len(leftText))]
+		}
+
+		fill := " "
+		if form.Ending > 0 {
+			fill = "─" // Volta bracket
+		}
+		gap := models.MeasureLength - len(leftText) - len(rightText)
+		line += style.Render(string(leftText) + strings.Repeat(fill, gap) + string(rightText))
+
+		if measureIdx < measuresInBlock-1 {
+			line += " "
+		}
+	}
+	return line
+}
+
+// JumpTo moves the cursor to a cell
+func (m *TabEditorModel) JumpTo(pos models.Position) {
+	if pos.Position < 0 || pos.Position >= m.tab.GetTotalLength() || pos.String < 0 || pos.String >= 6 {
+		return
+	}
+	m.pushJump()
+	m.cursor = pos
+	m.changed = true
+}
+
+// ClampCursor keeps the cursor within the tab, for a tab that may have
+// been shortened by another editor on it
+func (m *TabEditorModel) ClampCursor() {
+	m.moveTo(m.cursor)
+}
+
+// SetMaxStretch sets the widest chord span that is not marked as a
+// playability warning
+func (m *TabEditorModel) SetMaxStretch(frets int) {
+	m.maxStretch = frets
+	m.changed = true
+}
+
+// JumpToMeasure moves the cursor to the first column of a measure
+func (m *TabEditorModel) JumpToMeasure(measure int) {
+	if measure < 0 || measure >= m.tab.GetMeasureCount() {
+		return
+	}
+	m.pushJump()
+	m.cursor.Position = measure * models.MeasureLength
+	m.changed = true
+}
+
+// SetCursorString moves the cursor to a string, keeping its column
+func (m *TabEditorModel) SetCursorString(str int) {
+	if str < 0 || str >= 6 {
+		return
+	}
+	m.cursor.String = str
+	m.changed = true
+}
+
+// StepColumn moves the cursor delta columns along the tab
+func (m *TabEditorModel) StepColumn(delta int) {
+	pos := m.cursor.Position + delta
+	if pos < 0 || pos >= m.tab.GetTotalLength() {
+		return
+	}
+	m.cursor.Position = pos
 	m.updateViewportForCursor()
+	m.changed = true
+}
 
-	return m.viewport.View()
+// PlaceFret writes a fret on a string at the cursor column, as chosen on
+// the fretboard, and moves to the next column like insert mode does
+func (m *TabEditorModel) PlaceFret(str, fret int) {
+	m.status = ""
+	if !m.tab.PlaceFret(str, m.cursor.Position, fret) {
+		m.status = fmt.Sprintf("Fret %d does not fit next to the notes around it", fret)
+		return
+	}
+	m.changed = true
+	m.StepColumn(1)
+}
+
+// ClearFret removes the note on a string at the cursor column
+func (m *TabEditorModel) ClearFret(str int) {
+	if m.tab.RemoveNote(str, m.cursor.Position) {
+		m.changed = true
+	}
 }
 
 func (m TabEditorModel) HasChanged() bool {
 	return m.changed
 }
 
+// updateViewportForCursor scrolls just enough to show the whole block of
+// measures holding the cursor
 func (m *TabEditorModel) updateViewportForCursor() {
-	// Calculate which line the cursor is on
-	cursorLine := m.cursor.String + (m.cursor.Position/models.MeasureLength)*7 // 6 strings + 1 measure number line
+	m.ShowColumn(m.cursor.Position)
+}
+
+// ShowColumn scrolls just enough to show the whole block of measures
+// holding a column, such as the one being played. A block taller than
+// the editor shows its strings from the top.
+func (m *TabEditorModel) ShowColumn(pos int) {
+	blocks := m.blocks(m.tab.Playability(m.maxStretch))
+	if len(blocks) == 0 {
+		return
+	}
+	block := blocks[blockOf(blocks, pos/models.MeasureLength)]
+	offset := m.viewport.YOffset
+	switch {
+	case block.Height > m.viewport.Height:
+		offset = block.Top
+	case block.Line < offset:
+		offset = block.Line
+	case block.Line+block.Height > offset+m.viewport.Height:
+		offset = block.Line + block.Height - m.viewport.Height
+	}
+	m.scrollTo(offset, blocks)
+}
 
-	// If cursor is below visible area, scroll down
-	if cursorLine >= m.viewport.YOffset+m.viewport.Height {
-		m.viewport.ScrollDown(1)
+// scroll moves the view by lines, down when positive, leaving the cursor
+// where it is
+func (m *TabEditorModel) scroll(lines int) {
+	m.scrollTo(m.viewport.YOffset+lines, m.blocks(m.tab.Playability(m.maxStretch)))
+}
+
+// scrollTo sets the first line shown, kept within the content. The
+// viewport only gets its content in View, so it cannot clamp the offset.
+func (m *TabEditorModel) scrollTo(offset int, blocks []measureBlock) {
+	height := 0
+	if len(blocks) > 0 {
+		last := blocks[len(blocks)-1]
+		height = last.Line + last.Height
 	}
+	m.viewport.YOffset = max(min(offset, height-m.viewport.Height), 0)
+}
 
-	// If cursor is above visible area, scroll up
-	if cursorLine < m.viewport.YOffset {
-		m.viewport.ScrollUp(1)
+// pageCursor moves the cursor after a page scroll to the first (down) or
+// last (up) block shown whole, keeping its string and place in the block
+func (m *TabEditorModel) pageCursor(down bool) {
+	blocks := m.blocks(m.tab.Playability(m.maxStretch))
+	if len(blocks) == 0 {
+		return
+	}
+	cur := blockOf(blocks, m.cursor.Position/models.MeasureLength)
+	top, bottom := m.viewport.YOffset, m.viewport.YOffset+m.viewport.Height
+	target := cur
+	if down {
+		for target < len(blocks)-1 && blocks[target].Line < top {
+			target++
+		}
+	} else {
+		for target > 0 && blocks[target].Line+blocks[target].Height > bottom {
+			target--
+		}
+	}
+	if target == cur {
+		return
 	}
+	col := m.cursor.Position - blocks[cur].Start*models.MeasureLength
+	block := blocks[target]
+	m.cursor.Position = block.Start*models.MeasureLength + min(col, block.Count*models.MeasureLength-1)
+}
+
+// blockOf returns the index of the block holding a measure
+func blockOf(blocks []measureBlock, measure int) int {
+	for i, block := range blocks {
+		if measure < block.Start+block.Count {
+			return i
+		}
+	}
+	return len(blocks) - 1
 }
 
 func (m *TabEditorModel) ResetChanged() {
@@ -423,13 +1459,59 @@ func (m TabEditorModel) GetTab() *models.Tab {
 }
 
 func (m *TabEditorModel) SetEditMode(mode models.EditMode) {
+	if m.InTextEntry() {
+		m.commitLane()
+	}
+
+	// Mode changes made by the app, such as i and Esc, go into a macro
+	// being recorded as the keys that make them in the editor
+	if m.recording != 0 && !m.inUpdate && m.macroDepth == 0 && mode != m.editMode {
+		switch {
+		case mode == models.EditInsert && m.editMode == models.EditNormal:
+			m.macroKeys = append(m.macroKeys, firstKey(m.keys.Insert))
+		case mode == models.EditNormal:
+			m.macroKeys = append(m.macroKeys, firstKey(m.keys.Normal))
+		}
+	}
+
+	// An insertion is remembered as the keys that typed it, for .
+	if !m.replaying {
+		switch {
+		case mode == models.EditInsert && m.editMode != models.EditInsert:
+			m.insertKeys = []tea.KeyMsg{firstKey(m.keys.Insert)}
+		case mode != models.EditInsert && m.editMode == models.EditInsert:
+			if len(m.insertKeys) > 1 {
+				m.lastChange = append(m.insertKeys, firstKey(m.keys.Normal))
+			}
+			m.insertKeys = nil
+		}
+	}
+	m.command = nil
+	m.count = 0
+	m.operator = ""
+
 	m.editMode = mode
+	m.laneText = ""
+	m.anchor = m.cursor
+	if m.InTextEntry() {
+		m.loadLane()
+	}
 }
 
 func (m TabEditorModel) GetEditMode() models.EditMode {
 	return m.editMode
 }
 
+// ShiftScope returns how far column inserts and deletes shift content
+func (m TabEditorModel) ShiftScope() models.ShiftScope {
+	return m.shiftScope
+}
+
+// SetShiftScope sets how far column inserts and deletes shift content
+func (m *TabEditorModel) SetShiftScope(scope models.ShiftScope) {
+	m.shiftScope = scope
+}
+
 func (m TabEditorModel) GetCursor() models.Position {
 	return m.cursor
 }
@@ -0,0 +1,197 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// newLongEditor returns a narrow editor, two measures to a row, on a tab
+// of the given number of measures
+func newLongEditor(measures int) TabEditorModel {
+	m := newTestEditor()
+	for m.GetTab().GetMeasureCount() < measures {
+		m.GetTab().AddMeasure()
+	}
+	m.SetSize(40, 20)
+	return m
+}
+
+func TestCellAt(t *testing.T) {
+	m := newLongEditor(4)
+
+	// e|1-2-3-4-5-6-7-8- 1-2-3-4-5-6-7-8-|
+	// B|---------------- ----------------|
+	// ...
+	//           1                2
+	tests := []struct {
+		name string
+		x, y int
+		cell models.Position
+		ok   bool
+	}{
+		{"first cell", 2, 0, models.Position{String: 0, Position: 0}, true},
+		{"lower string", 5, 3, models.Position{String: 3, Position: 3}, true},
+		{"string label", 0, 0, models.Position{}, false},
+		{"opening bar line", 1, 2, models.Position{}, false},
+		{"bar line between measures", 18, 0, models.Position{}, false},
+		{"after the bar line", 19, 1, models.Position{String: 1, Position: 16}, true},
+		{"last cell of the row", 34, 5, models.Position{String: 5, Position: 31}, true},
+		{"closing bar line", 35, 0, models.Position{}, false},
+		{"measure numbers", 11, 6, models.Position{}, false},
+		{"second row", 3, 9, models.Position{String: 1, Position: 33}, true},
+		{"past the last string", 2, 30, models.Position{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cell, ok := m.cellAt(tt.x, tt.y)
+			if ok != tt.ok || ok && cell != tt.cell {
+				t.Errorf("cellAt(%d, %d): expected %+v %v, got %+v %v", tt.x, tt.y, tt.cell, tt.ok, cell, ok)
+			}
+		})
+	}
+}
+
+func TestCellAtScrolled(t *testing.T) {
+	m := newLongEditor(16)
+	m.GetTab().SetChord(40, "G") // A chord lane above the strings of that row
+	m, _ = m.Update(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	if m.viewport.YOffset == 0 {
+		t.Fatal("Expected the wheel to scroll the view")
+	}
+
+	// Every cell found under the pointer is the one drawn there
+	hits := 0
+	for y, line := range strings.Split(m.View(), "\n") {
+		for x, char := range []rune(line) {
+			cell, ok := m.cellAt(x, y)
+			if !ok {
+				continue
+			}
+			hits++
+			if expected := rune(m.GetTab().Content[cell.String][cell.Position]); char != expected {
+				t.Errorf("cellAt(%d, %d) = %+v holding %q, but %q is drawn there", x, y, cell, expected, char)
+			}
+		}
+	}
+	if hits == 0 {
+		t.Fatal("Expected cells under the pointer")
+	}
+
+	// The top string of the second row is under its chord lane
+	top := -1
+	for y, line := range strings.Split(m.View(), "\n") {
+		if strings.HasPrefix(line, "e|") {
+			top = y
+			break
+		}
+	}
+	if top < 1 {
+		t.Fatalf("Expected the second row in view below its chord lane:\n%s", m.View())
+	}
+	if cell, ok := m.cellAt(10, top-1); ok {
+		t.Errorf("Expected no cell on the chord lane, got %+v", cell)
+	}
+
+	// A click there moves the cursor, wherever the view is scrolled to
+	m, _ = m.Update(tea.MouseMsg{X: 4, Y: top, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if expected := (models.Position{String: 0, Position: 34}); m.GetCursor() != expected {
+		t.Errorf("Expected the click to move the cursor to %+v, got %+v", expected, m.GetCursor())
+	}
+}
+
+// shown reports whether the whole block of measures holding a column is
+// in view
+func shown(m TabEditorModel, pos int) bool {
+	blocks := m.blocks(m.tab.Playability(m.maxStretch))
+	block := blocks[blockOf(blocks, pos/models.MeasureLength)]
+	return block.Line >= m.viewport.YOffset && block.Line+block.Height <= m.viewport.YOffset+m.viewport.Height
+}
+
+func TestViewportFollowsCursor(t *testing.T) {
+	m := newLongEditor(16) // 8 rows of 2 measures, 2 rows in view
+	blocks := m.blocks(m.tab.Playability(m.maxStretch))
+	if len(blocks) != 8 || !shown(m, 0) || shown(m, 4*models.MeasureLength) {
+		t.Fatalf("Expected 8 rows with the first two in view, got %+v at offset %d", blocks, m.viewport.YOffset)
+	}
+
+	// Moving down a row at a time scrolls just far enough to show it
+	for measure := 1; measure < 16; measure++ {
+		m = typeKeys(m, "w")
+		pos := m.GetCursor().Position
+		if !shown(m, pos) {
+			t.Fatalf("Measure %d: expected the cursor row in view at offset %d", measure, m.viewport.YOffset)
+		}
+		block := blocks[blockOf(blocks, measure)]
+		if block.Line+block.Height > m.viewport.Height &&
+			block.Line+block.Height != m.viewport.YOffset+m.viewport.Height {
+			t.Errorf("Measure %d: expected the cursor row at the bottom of the view, offset %d", measure, m.viewport.YOffset)
+		}
+	}
+	last := m.viewport.YOffset
+
+	// Moving up within the rows in view leaves the view alone
+	m = typeKeys(m, "2b")
+	if m.viewport.YOffset != last {
+		t.Errorf("Expected the view to stay at %d, got %d", last, m.viewport.YOffset)
+	}
+	m = typeKeys(m, "gk")
+	if m.viewport.YOffset != last {
+		t.Errorf("Expected the view to stay at %d within the row, got %d", last, m.viewport.YOffset)
+	}
+
+	// Going back to the start of the string scrolls to the top
+	m = pressKeys(m, "home")
+	if m.viewport.YOffset != 0 {
+		t.Errorf("Expected the view back at the top, got offset %d", m.viewport.YOffset)
+	}
+
+	// A larger editor shows more rows and scrolls less
+	m.SetSize(40, 40)
+	m = pressKeys(m, "end")
+	if !shown(m, m.GetCursor().Position) {
+		t.Errorf("Expected the last row in view after a resize, offset %d", m.viewport.YOffset)
+	}
+}
+
+func TestShowColumnFollowsPlayback(t *testing.T) {
+	m := newLongEditor(16)
+	cursor := m.GetCursor()
+
+	// The playhead runs through the tab, each column played highlighted on
+	// every string, as the app does on each tick when following playback
+	for pos := 0; pos < m.GetTab().GetTotalLength(); pos += 4 {
+		var played []models.Position
+		for str := 0; str < 6; str++ {
+			played = append(played, models.Position{String: str, Position: pos})
+		}
+		m.SetHighlightedPositions(played)
+		m.ShowColumn(pos)
+		if !shown(m, pos) {
+			t.Fatalf("Column %d: expected the row played in view at offset %d", pos, m.viewport.YOffset)
+		}
+	}
+	if m.viewport.YOffset == 0 {
+		t.Error("Expected the view to scroll along with playback")
+	}
+	if m.GetCursor() != cursor {
+		t.Errorf("Expected following playback to leave the cursor at %+v, got %+v", cursor, m.GetCursor())
+	}
+
+	// A repeat sends the playhead back to the start
+	m.ShowColumn(0)
+	if m.viewport.YOffset != 0 {
+		t.Errorf("Expected the view back at the top for a repeat, got offset %d", m.viewport.YOffset)
+	}
+
+	// A column already in view does not scroll
+	m.ShowColumn(3 * models.MeasureLength)
+	offset := m.viewport.YOffset
+	m.ShowColumn(2 * models.MeasureLength)
+	if m.viewport.YOffset != offset {
+		t.Errorf("Expected the view to stay at %d, got %d", offset, m.viewport.YOffset)
+	}
+}
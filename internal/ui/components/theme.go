@@ -0,0 +1,187 @@
+// internal/ui/components/theme.go
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme holds the styles of the interface by the role they play
+type Theme struct {
+	Title      lipgloss.Style // Titles, normal mode and the border of a focused panel
+	Faint      lipgloss.Style // Hints, bar lines, empty states and unfocused borders
+	Selected   lipgloss.Style // Cursor of lists and of the tab in normal mode
+	Insert     lipgloss.Style // Tab cursor in insert mode and the focused fret
+	Selection  lipgloss.Style // Block selected in select mode
+	Playing    lipgloss.Style // Notes being played
+	Match      lipgloss.Style // Search matches
+	Label      lipgloss.Style // String names and the closing bar line
+	Warning    lipgloss.Style // Playability warnings and macro recording
+	Section    lipgloss.Style // Section names and playback
+	Form       lipgloss.Style // Repeat and navigation marks, capo, insert mode, scale roots
+	Chord      lipgloss.Style // Chord symbols and the chord and lyric modes
+	Analysis   lipgloss.Style // Recognized chords and select mode
+	LaneCursor lipgloss.Style // Text being typed in a lane
+	Scale      lipgloss.Style // Scale tones on the fretboard
+	Status     lipgloss.Style // Status bar with nothing to report
+	Message    lipgloss.Style // Status bar message
+}
+
+// ThemeNames lists the built-in themes, the first being the default
+var ThemeNames = []string{"dark", "light", "high-contrast", "mono"}
+
+// ThemeNamed returns a built-in theme
+func ThemeNamed(name string) (Theme, bool) {
+	switch name {
+	case "dark":
+		return DarkTheme(), true
+	case "light":
+		return LightTheme(), true
+	case "high-contrast":
+		return HighContrastTheme(), true
+	case "mono":
+		return MonoTheme(), true
+	}
+	return Theme{}, false
+}
+
+// fg and on make the usual styles of a theme: colored text, and colored
+// text on a background
+func fg(color string) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+}
+
+func on(fore, back string) lipgloss.Style {
+	return fg(fore).Background(lipgloss.Color(back))
+}
+
+// DarkTheme uses the 16 ANSI colors, for a dark background
+func DarkTheme() Theme {
+	return Theme{
+		Title:      fg("12").Bold(true),
+		Faint:      fg("8"),
+		Selected:   on("15", "12"),
+		Insert:     on("0", "11"),
+		Selection:  on("15", "8"),
+		Playing:    on("0", "37"),
+		Match:      on("0", "3"),
+		Label:      fg("14"),
+		Warning:    fg("9"),
+		Section:    fg("10").Bold(true),
+		Form:       fg("11").Bold(true),
+		Chord:      fg("13").Bold(true),
+		Analysis:   fg("5").Italic(true),
+		LaneCursor: on("0", "13"),
+		Scale:      fg("6"),
+		Status:     on("15", "8"),
+		Message:    on("0", "11"),
+	}
+}
+
+// LightTheme uses darker 256 colors that read on a light background
+func LightTheme() Theme {
+	return Theme{
+		Title:      fg("25").Bold(true),
+		Faint:      fg("244"),
+		Selected:   on("231", "25"),
+		Insert:     on("16", "214"),
+		Selection:  on("16", "252"),
+		Playing:    on("16", "117"),
+		Match:      on("16", "229"),
+		Label:      fg("30"),
+		Warning:    fg("160"),
+		Section:    fg("28").Bold(true),
+		Form:       fg("130").Bold(true),
+		Chord:      fg("90").Bold(true),
+		Analysis:   fg("97").Italic(true),
+		LaneCursor: on("16", "219"),
+		Scale:      fg("31"),
+		Status:     on("16", "252"),
+		Message:    on("231", "25"),
+	}
+}
+
+// HighContrastTheme keeps to bold, bright colors and black on white, for
+// glare and small screens
+func HighContrastTheme() Theme {
+	return Theme{
+		Title:      fg("15").Bold(true).Underline(true),
+		Faint:      fg("7"),
+		Selected:   on("0", "15").Bold(true),
+		Insert:     on("0", "11").Bold(true),
+		Selection:  on("0", "14"),
+		Playing:    on("0", "10").Bold(true),
+		Match:      on("0", "13"),
+		Label:      fg("15").Bold(true),
+		Warning:    fg("9").Bold(true),
+		Section:    fg("10").Bold(true),
+		Form:       fg("11").Bold(true),
+		Chord:      fg("13").Bold(true),
+		Analysis:   fg("14").Bold(true),
+		LaneCursor: on("0", "15").Bold(true),
+		Scale:      fg("14").Bold(true),
+		Status:     on("0", "15"),
+		Message:    on("0", "11").Bold(true),
+	}
+}
+
+// MonoTheme uses no color at all, only bold, underline and reverse video.
+// It is the default when NO_COLOR is set.
+func MonoTheme() Theme {
+	plain := lipgloss.NewStyle()
+	return Theme{
+		Title:      plain.Bold(true),
+		Faint:      plain.Faint(true),
+		Selected:   plain.Reverse(true),
+		Insert:     plain.Reverse(true).Underline(true),
+		Selection:  plain.Underline(true),
+		Playing:    plain.Bold(true).Underline(true),
+		Match:      plain.Bold(true),
+		Label:      plain.Bold(true),
+		Warning:    plain.Bold(true).Underline(true),
+		Section:    plain.Bold(true),
+		Form:       plain.Bold(true),
+		Chord:      plain.Bold(true),
+		Analysis:   plain.Italic(true),
+		LaneCursor: plain.Reverse(true),
+		Scale:      plain.Bold(true),
+		Status:     plain.Reverse(true),
+		Message:    plain.Reverse(true).Bold(true),
+	}
+}
+
+// roles returns the styles by the names used in the config file
+func (t *Theme) roles() map[string]*lipgloss.Style {
+	return map[string]*lipgloss.Style{
+		"title": &t.Title, "faint": &t.Faint, "selected": &t.Selected, "insert": &t.Insert,
+		"selection": &t.Selection, "playing": &t.Playing, "match": &t.Match, "label": &t.Label,
+		"warning": &t.Warning, "section": &t.Section, "form": &t.Form, "chord": &t.Chord,
+		"analysis": &t.Analysis, "lane_cursor": &t.LaneCursor, "scale": &t.Scale,
+		"status": &t.Status, "message": &t.Message,
+	}
+}
+
+// SetColors changes the colors of a role, such as "selected". An empty
+// color keeps the one of the theme. Colors are ANSI numbers or hex values.
+func (t *Theme) SetColors(role, fore, back string) error {
+	style, ok := t.roles()[role]
+	if !ok {
+		return fmt.Errorf("unknown color role %q", role)
+	}
+	if fore != "" {
+		*style = style.Foreground(lipgloss.Color(fore))
+	}
+	if back != "" {
+		*style = style.Background(lipgloss.Color(back))
+	}
+	return nil
+}
+
+// border returns the border color of a panel
+func (t Theme) border(focused bool) lipgloss.TerminalColor {
+	if focused {
+		return t.Title.GetForeground()
+	}
+	return t.Faint.GetForeground()
+}
@@ -0,0 +1,164 @@
+// internal/ui/components/warning_list.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+)
+
+// WarningListModel lists the playability warnings of the tab next to the
+// editor, sharing the side panel with the section outline
+type WarningListModel struct {
+	warnings []models.Warning
+	labels   [6]string
+	cursor   int
+	stretch  int
+	focused  bool
+	height   int
+	theme    Theme
+}
+
+func NewWarningList() WarningListModel {
+	return WarningListModel{stretch: models.DefaultMaxStretch, theme: DarkTheme()}
+}
+
+func (m *WarningListModel) SetTheme(theme Theme) {
+	m.theme = theme
+}
+
+// SetWarnings refreshes the list for the tab's string names
+func (m *WarningListModel) SetWarnings(warnings []models.Warning, labels [6]string) {
+	m.warnings = warnings
+	m.labels = labels
+	if m.cursor >= len(warnings) {
+		m.cursor = len(warnings) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *WarningListModel) SetHeight(height int) {
+	m.height = height
+}
+
+// MaxStretch is the widest chord span that is not reported
+func (m WarningListModel) MaxStretch() int {
+	return m.stretch
+}
+
+// SetMaxStretch sets the widest chord span that is not reported
+func (m *WarningListModel) SetMaxStretch(frets int) {
+	m.stretch = frets
+}
+
+func (m *WarningListModel) Focus() {
+	m.focused = true
+}
+
+func (m *WarningListModel) Blur() {
+	m.focused = false
+}
+
+func (m WarningListModel) Focused() bool {
+	return m.focused
+}
+
+func (m WarningListModel) Update(msg tea.Msg) (WarningListModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "j", "down":
+			if m.cursor < len(m.warnings)-1 {
+				m.cursor++
+			}
+		case "home", "g":
+			m.cursor = 0
+		case "end", "G":
+			m.cursor = len(m.warnings) - 1
+		case "+", "=":
+			if m.stretch < models.MaxFret {
+				m.stretch++
+			}
+		case "-":
+			if m.stretch > 1 {
+				m.stretch--
+			}
+		}
+	}
+	return m, nil
+}
+
+// Selected returns the warning under the list cursor
+func (m WarningListModel) Selected() (models.Warning, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.warnings) {
+		return models.Warning{}, false
+	}
+	return m.warnings[m.cursor], true
+}
+
+func (m WarningListModel) View() string {
+	innerWidth := OutlineWidth - 4 // Border and padding
+	faint := m.theme.Faint
+
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Playability (%d)", len(m.warnings))),
+		faint.Render(fmt.Sprintf("Max stretch: %d frets", m.stretch)),
+	}
+	if len(m.warnings) == 0 {
+		lines = append(lines, faint.Render("Nothing awkward found."))
+	}
+
+	// Keep the cursor in view when the list is longer than the panel
+	visible := len(m.warnings)
+	if m.height > 0 {
+		visible = min(visible, max(m.height-10, 3))
+	}
+	first := 0
+	if m.cursor >= visible {
+		first = m.cursor - visible + 1
+	}
+
+	for i := first; i < len(m.warnings) && i < first+visible; i++ {
+		w := m.warnings[i]
+		where := fmt.Sprintf("%d:%d", w.Position/models.MeasureLength+1, w.Position%models.MeasureLength+1)
+		str := ""
+		if w.String >= 0 {
+			str = m.labels[w.String]
+		}
+		item := fmt.Sprintf("%-6s %-2s %s", where, str, w.Kind)
+		if len(item) > innerWidth {
+			item = item[:innerWidth]
+		}
+
+		style := lipgloss.NewStyle()
+		if m.focused && i == m.cursor {
+			style = m.theme.Selected
+		}
+		lines = append(lines, style.Render(item))
+	}
+
+	if w, ok := m.Selected(); ok && m.focused {
+		lines = append(lines, "", m.theme.Warning.
+			Width(innerWidth).Render(w.Message))
+	}
+
+	if m.focused {
+		lines = append(lines, "", faint.Render("Enter: Jump • Esc: Back\n+/-: Max stretch • W: Close"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.border(m.focused)).
+		Padding(0, 1).
+		Width(OutlineWidth - 2).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,170 @@
+// internal/ui/split.go
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+	"github.com/Cod-e-Codes/tuitar/internal/ui/components"
+)
+
+// splitLayout is how two editor panes share the screen
+type splitLayout int
+
+const (
+	splitNone    splitLayout = iota
+	splitStacked             // One pane above the other (:split)
+	splitSide                // Panes side by side (:vsplit)
+)
+
+// The focused pane is always tabEditor, so the keys, panels and commands
+// act on it unchanged; the other pane waits in Model.other and the two
+// swap places when the focus moves.
+
+// openSplit opens a second pane on a tab and focuses it. Given the tab
+// already open, both panes edit the same tab at their own cursor.
+func (m *Model) openSplit(layout splitLayout, tab *models.Tab) {
+	prev := m.tabEditor
+	same := tab == m.state.CurrentTab
+	m.split = layout
+	m.otherFirst = true // The new pane goes below or to the right
+	m.openEditor(tab)
+	m.other = prev
+	if same {
+		m.tabEditor.JumpTo(prev.GetCursor())
+	}
+	m.resizeEditor()
+}
+
+// savedTab returns a copy of a saved tab to edit, or the tab open in the
+// pane that stays when it is the same one, so the two panes never hold
+// copies of one tab that would save over each other
+func (m Model) savedTab(i int, open *models.Tab) *models.Tab {
+	if open != nil && open.ID != 0 && open.ID == m.tabs[i].ID {
+		return open
+	}
+	tab := m.tabs[i]
+	return &tab
+}
+
+// closeSplit keeps the focused pane only (:only)
+func (m *Model) closeSplit() {
+	m.split = splitNone
+	m.other = components.TabEditorModel{}
+	m.resizeEditor()
+}
+
+// switchPane moves the focus to the other pane. The register and macros
+// go along, so notes yanked in one pane paste in the other.
+func (m *Model) switchPane() {
+	m.other.SetRegister(m.tabEditor.Register())
+	m.other.SetMacros(m.tabEditor.Macros())
+	m.tabEditor.SetHighlightedPositions(nil)
+
+	m.tabEditor, m.other = m.other, m.tabEditor
+	m.otherFirst = !m.otherFirst
+
+	// The tab may have shrunk under the cursor when both panes edit it
+	m.tabEditor.ClampCursor()
+	m.state.CurrentTab = m.tabEditor.GetTab()
+	m.state.EditMode = m.tabEditor.GetEditMode()
+	m.statusBar.SetStatus("Editing: " + m.state.CurrentTab.Name)
+}
+
+// paneSize returns the width and height of each pane's view, within an
+// editor area of the given size. Every pane has a header line with its
+// tab name; stacked panes are parted by a blank line and side by side
+// panes by a rule.
+func (m Model) paneSize(width, height int) (int, int) {
+	// The editor keeps 4 lines of the height it is given for itself
+	switch m.split {
+	case splitStacked:
+		return width, max((height-4-3)/2, 1)
+	case splitSide:
+		return (width - 1) / 2, max(height-4-1, 1)
+	}
+	return width, height - 4
+}
+
+// resizePanes gives each pane its share of the editor area
+func (m *Model) resizePanes(width, height int) {
+	paneWidth, paneHeight := m.paneSize(width, height)
+	m.tabEditor.SetSize(paneWidth, paneHeight+4)
+	if m.split != splitNone {
+		m.other.SetSize(paneWidth, paneHeight+4)
+	}
+}
+
+// paneOrigin returns where the view of the first or second pane starts
+// in the editor area, below its header
+func (m Model) paneOrigin(second bool) (x, y int) {
+	if !second {
+		return 0, 1
+	}
+	paneWidth, paneHeight := m.paneSize(m.editorArea())
+	if m.split == splitSide {
+		return paneWidth + 1, 1
+	}
+	return 0, paneHeight + 3
+}
+
+// updatePaneMouse sends a mouse event to the pane under the pointer. A
+// click in the other pane focuses it, when not in the middle of an edit;
+// drags stay with the focused pane.
+func (m Model) updatePaneMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	x, y := m.paneOrigin(true)
+	second := m.split == splitSide && msg.X >= x || m.split == splitStacked && msg.Y >= y-1
+	onOther := second != m.otherFirst
+
+	if onOther && msg.Action == tea.MouseActionPress {
+		switch {
+		case msg.Button == tea.MouseButtonWheelUp || msg.Button == tea.MouseButtonWheelDown:
+			// The wheel scrolls the other pane without focusing it
+			m.other, _ = m.other.Update(msg)
+			return m, nil
+		case msg.Button != tea.MouseButtonLeft || m.state.EditMode != models.EditNormal ||
+			m.tabEditor.InTextEntry() || m.tabEditor.PendingKey():
+			return m, nil
+		}
+		m.switchPane()
+	}
+
+	ox, oy := m.paneOrigin(m.otherFirst)
+	msg.X, msg.Y = msg.X-ox, msg.Y-oy
+	var cmd tea.Cmd
+	m.tabEditor, cmd = m.tabEditor.Update(msg)
+	m.syncEditor()
+	return m, cmd
+}
+
+// renderPanes draws the editor panes, each under a header naming its tab
+// with the focused one highlighted
+func (m Model) renderPanes() string {
+	if m.split == splitNone {
+		return m.tabEditor.View()
+	}
+
+	paneWidth, _ := m.paneSize(m.editorArea())
+	pane := func(editor components.TabEditorModel, focused bool) string {
+		style := m.theme.Faint
+		if focused {
+			style = m.theme.Title
+		}
+		header := style.MaxWidth(paneWidth).Render(editor.GetTab().Name)
+		return lipgloss.JoinVertical(lipgloss.Left, header, editor.View())
+	}
+	first, second := pane(m.tabEditor, true), pane(m.other, false)
+	if m.otherFirst {
+		first, second = second, first
+	}
+
+	if m.split == splitStacked {
+		return lipgloss.JoinVertical(lipgloss.Left, first, "", second)
+	}
+	height := max(lipgloss.Height(first), lipgloss.Height(second))
+	rule := m.theme.Faint.Render(strings.TrimSuffix(strings.Repeat("│\n", height), "\n"))
+	return lipgloss.JoinHorizontal(lipgloss.Top, first, rule, second)
+}
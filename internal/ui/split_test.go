@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Cod-e-Codes/tuitar/internal/models"
+	"github.com/Cod-e-Codes/tuitar/internal/ui/components"
+)
+
+// newTestModel returns an app on saved tabs, without the storage and
+// audio it does not need for panes and commands
+func newTestModel(names ...string) Model {
+	var tabs []models.Tab
+	for i, name := range names {
+		tab := *models.NewEmptyTab(name)
+		tab.ID = i + 1
+		tabs = append(tabs, tab)
+	}
+	m := Model{
+		tabs:       tabs,
+		keys:       NewKeyMap(),
+		editorKeys: components.NewEditorKeyMap(),
+		theme:      components.DarkTheme(),
+		statusBar:  components.NewStatusBar(),
+		warnings:   components.NewWarningList(),
+		textInput:  textinput.New(),
+		windowSize: tea.WindowSizeMsg{Width: 100, Height: 40},
+	}
+	m.state.EditMode = models.EditNormal
+	return m
+}
+
+func TestSplitSharesOpenTab(t *testing.T) {
+	m := newTestModel("Lead", "Harmony")
+	m.runCommand("e Lead")
+	lead := m.state.CurrentTab
+
+	// The tab being edited, named, is shared rather than opened twice
+	m.runCommand("split Lead")
+	if m.split != splitStacked {
+		t.Fatalf("Expected a stacked split, got %v", m.split)
+	}
+	if m.tabEditor.GetTab() != lead || m.other.GetTab() != lead {
+		t.Error("Expected both panes to edit the open tab")
+	}
+
+	// So is the tab of the other pane, opened in the focused one
+	m.runCommand("only")
+	m.runCommand("vsplit Harmony")
+	if m.tabEditor.GetTab() == lead {
+		t.Fatal("Expected the new pane on Harmony")
+	}
+	m.runCommand("e Lead")
+	if m.tabEditor.GetTab() != lead {
+		t.Error("Expected :e to share the tab open in the other pane")
+	}
+}
+
+func TestSplitRefusedWhileSplit(t *testing.T) {
+	m := newTestModel("Lead", "Harmony")
+	m.runCommand("e Lead")
+	m.runCommand("split")
+	lead := m.other.GetTab()
+
+	for _, line := range []string{"split", "vsplit Harmony"} {
+		m.runCommand(line)
+		if m.split != splitStacked || m.other.GetTab() != lead {
+			t.Errorf(":%s: expected the other pane kept", line)
+		}
+		if !strings.Contains(m.statusBar.View(), "two panes") {
+			t.Errorf(":%s: expected the split refused, status %q", line, m.statusBar.View())
+		}
+	}
+
+	m.runCommand("only")
+	m.runCommand("vsplit Harmony")
+	if m.split != splitSide || m.tabEditor.GetTab().Name != "Harmony" {
+		t.Errorf("Expected a split after :only, got %v on %s", m.split, m.tabEditor.GetTab().Name)
+	}
+}
+
+func TestCompleteTabNames(t *testing.T) {
+	m := newTestModel("My Lead", "My Harmony")
+	for _, line := range []string{"e My L", "split My L", "vsplit My L", "vs my l"} {
+		m.completions = nil
+		m.textInput.SetValue(line)
+		m.complete(false)
+		name, _, _ := strings.Cut(line, " ")
+		if expected := name + " My Lead "; m.textInput.Value() != expected {
+			t.Errorf("%q: expected %q, got %q", line, expected, m.textInput.Value())
+		}
+	}
+}
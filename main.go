@@ -7,23 +7,42 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 
+	"github.com/Cod-e-Codes/tuitar/internal/config"
 	"github.com/Cod-e-Codes/tuitar/internal/storage"
 	"github.com/Cod-e-Codes/tuitar/internal/ui"
 )
 
 func main() {
+	// Load preferences and keybindings; without a config file the
+	// defaults apply
+	cfg := config.Default()
+	if path, err := config.Path(); err == nil {
+		if cfg, err = config.Load(path); err != nil {
+			log.Fatalf("Failed to load config %s: %v", path, err)
+		}
+	}
+
+	// NO_COLOR also makes lipgloss drop bold and reverse video, which the
+	// mono theme picked for it needs to show the cursor. The themes decide
+	// on color instead.
+	if termenv.EnvNoColor() {
+		lipgloss.SetColorProfile(termenv.ColorProfile())
+	}
+
 	// Initialize storage
-	storage, err := storage.NewSQLiteStorage("tabs.db")
+	storage, err := storage.NewSQLiteStorage(cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to initialize storage:", err)
 	}
 
 	// Create the main application model
-	m := ui.NewModel(storage)
+	m := ui.NewModel(storage, cfg)
 
 	// Start the Bubble Tea program
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running program: %v", err)
 		os.Exit(1)